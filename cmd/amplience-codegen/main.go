@@ -0,0 +1,132 @@
+// Command amplience-codegen generates Go types from Amplience content type
+// schemas, read from local files or fetched from a hub. It is meant to be used
+// with go generate:
+//
+//	//go:generate go run github.com/labd/amplience-go-sdk/cmd/amplience-codegen -package models -o models.go schemas
+//
+// Directories are searched for `.json` files. When -hub is given, the schemas
+// of the hub are fetched using the credentials in the AMPLIENCE_CLIENT_ID and
+// AMPLIENCE_CLIENT_SECRET environment variables.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/labd/amplience-go-sdk/content"
+	"github.com/labd/amplience-go-sdk/schema"
+)
+
+func main() {
+	packageName := flag.String("package", "models", "name of the generated package")
+	output := flag.String("o", "", "file to write the generated code to, defaults to stdout")
+	hubID := flag.String("hub", "", "id of a hub to fetch the content type schemas from")
+	omitMeta := flag.Bool("omit-meta", false, "leave out the _meta field, for use with content.DecodeBody")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: amplience-codegen [flags] [schema files or directories]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	config := schema.GeneratorConfig{Package: *packageName, OmitMeta: *omitMeta}
+	if err := run(config, *output, *hubID, flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "amplience-codegen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(config schema.GeneratorConfig, output string, hubID string, paths []string) error {
+	if hubID == "" && len(paths) == 0 {
+		return fmt.Errorf("no schema files or hub given")
+	}
+
+	schemas, err := loadFiles(paths)
+	if err != nil {
+		return err
+	}
+
+	if hubID != "" {
+		fetched, err := fetchSchemas(hubID)
+		if err != nil {
+			return err
+		}
+		schemas = append(schemas, fetched...)
+	}
+
+	code, err := schema.Generate(config, schemas...)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(code)
+		return err
+	}
+	return os.WriteFile(output, code, 0o644)
+}
+
+func loadFiles(paths []string) ([]*schema.Schema, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".json") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	var result []*schema.Schema
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		s, err := schema.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func fetchSchemas(hubID string) ([]*schema.Schema, error) {
+	client, err := content.NewClient(&content.ClientConfig{
+		ClientID:     os.Getenv("AMPLIENCE_CLIENT_ID"),
+		ClientSecret: os.Getenv("AMPLIENCE_CLIENT_SECRET"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := client.ContentTypeSchemaGetAll(hubID, content.StatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*schema.Schema
+	for _, item := range items {
+		s, err := schema.ParseContentTypeSchema(item)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
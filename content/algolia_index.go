@@ -1,6 +1,7 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -66,6 +67,32 @@ func (r *AssignedContentTypeResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *AssignedContentTypeResults) Next(client *Client) (AssignedContentTypeResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *AssignedContentTypeResults) NextWithContext(ctx context.Context, client *Client) (AssignedContentTypeResults, error) {
+	result := AssignedContentTypeResults{}
+	err := client.followRelation(ctx, "AssignedContentTypeResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *AssignedContentTypeResults) Prev(client *Client) (AssignedContentTypeResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *AssignedContentTypeResults) PrevWithContext(ctx context.Context, client *Client) (AssignedContentTypeResults, error) {
+	result := AssignedContentTypeResults{}
+	err := client.followRelation(ctx, "AssignedContentTypeResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 func (r *AlgoliaIndexResults) UnmarshalJSON(data []byte) error {
 	generic := GenericListResults{}
 	if err := json.Unmarshal(data, &generic); err != nil {
@@ -81,14 +108,57 @@ func (r *AlgoliaIndexResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *AlgoliaIndexResults) Next(client *Client) (AlgoliaIndexResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *AlgoliaIndexResults) NextWithContext(ctx context.Context, client *Client) (AlgoliaIndexResults, error) {
+	result := AlgoliaIndexResults{}
+	err := client.followRelation(ctx, "AlgoliaIndexResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *AlgoliaIndexResults) Prev(client *Client) (AlgoliaIndexResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *AlgoliaIndexResults) PrevWithContext(ctx context.Context, client *Client) (AlgoliaIndexResults, error) {
+	result := AlgoliaIndexResults{}
+	err := client.followRelation(ctx, "AlgoliaIndexResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
+// GetWebhook returns the webhook which keeps the index up to date for the
+// Content Type
+func (r *AssignedContentType) GetWebhook(client *Client) (Webhook, error) {
+	return r.GetWebhookWithContext(context.Background(), client)
+}
+
+// GetWebhookWithContext is the same as GetWebhook with a custom context
+func (r *AssignedContentType) GetWebhookWithContext(ctx context.Context, client *Client) (Webhook, error) {
+	result := Webhook{}
+	err := client.followRelation(ctx, "AssignedContentType.GetWebhook", r.Links, "webhook", &result)
+	return result, err
+}
+
 func (client *Client) AlgoliaIndexCreate(hub_id string, input AlgoliaIndexInput) (AlgoliaIndex, error) {
+	return client.AlgoliaIndexCreateWithContext(context.Background(), hub_id, input)
+}
+
+func (client *Client) AlgoliaIndexCreateWithContext(ctx context.Context, hub_id string, input AlgoliaIndexInput) (AlgoliaIndex, error) {
 	result := AlgoliaIndex{}
 	body, err := json.Marshal(input)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes", hub_id)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "AlgoliaIndexCreate", http.MethodPost, endpoint, body, &result)
 	if err != nil {
 		return result, err
 	}
@@ -96,6 +166,10 @@ func (client *Client) AlgoliaIndexCreate(hub_id string, input AlgoliaIndexInput)
 }
 
 func (client *Client) AlgoliaIndexUpdate(hubID string, current AlgoliaIndex, input AlgoliaIndexInput) (AlgoliaIndex, error) {
+	return client.AlgoliaIndexUpdateWithContext(context.Background(), hubID, current, input)
+}
+
+func (client *Client) AlgoliaIndexUpdateWithContext(ctx context.Context, hubID string, current AlgoliaIndex, input AlgoliaIndexInput) (AlgoliaIndex, error) {
 	result := AlgoliaIndex{}
 
 	body, err := createUpdatePatch(
@@ -111,42 +185,62 @@ func (client *Client) AlgoliaIndexUpdate(hubID string, current AlgoliaIndex, inp
 	}
 
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes/%s", hubID, current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "AlgoliaIndexUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) AlgoliaIndexGet(hub_id string, id string) (AlgoliaIndex, error) {
+	return client.AlgoliaIndexGetWithContext(context.Background(), hub_id, id)
+}
+
+func (client *Client) AlgoliaIndexGetWithContext(ctx context.Context, hub_id string, id string) (AlgoliaIndex, error) {
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes/%s", hub_id, id)
 	result := AlgoliaIndex{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "AlgoliaIndexGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) AlgoliaIndexDelete(hub_id string, id string) (AlgoliaIndex, error) {
+	return client.AlgoliaIndexDeleteWithContext(context.Background(), hub_id, id)
+}
+
+func (client *Client) AlgoliaIndexDeleteWithContext(ctx context.Context, hub_id string, id string) (AlgoliaIndex, error) {
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes/%s", hub_id, id)
 	result := AlgoliaIndex{}
 
-	err := client.request(http.MethodDelete, endpoint, nil, &result)
+	err := client.request(ctx, "AlgoliaIndexDelete", http.MethodDelete, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) AlgoliaIndexList(hub_id string) (AlgoliaIndexResults, error) {
+	return client.AlgoliaIndexListWithContext(context.Background(), hub_id)
+}
+
+func (client *Client) AlgoliaIndexListWithContext(ctx context.Context, hub_id string) (AlgoliaIndexResults, error) {
 	result := AlgoliaIndexResults{}
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes", hub_id)
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "AlgoliaIndexList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) AlgoliaIndexSettingsGet(hub_id string, id string) (AlgoliaIndexSettings, error) {
+	return client.AlgoliaIndexSettingsGetWithContext(context.Background(), hub_id, id)
+}
+
+func (client *Client) AlgoliaIndexSettingsGetWithContext(ctx context.Context, hub_id string, id string) (AlgoliaIndexSettings, error) {
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes/%s/settings", hub_id, id)
 	result := AlgoliaIndexSettings{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "AlgoliaIndexSettingsGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) AlgoliaIndexSettingsUpdate(hub_id string, id string, input AlgoliaIndexSettings) (AlgoliaIndexSettings, error) {
+	return client.AlgoliaIndexSettingsUpdateWithContext(context.Background(), hub_id, id, input)
+}
+
+func (client *Client) AlgoliaIndexSettingsUpdateWithContext(ctx context.Context, hub_id string, id string, input AlgoliaIndexSettings) (AlgoliaIndexSettings, error) {
 	result := AlgoliaIndexSettings{}
 
 	body, err := createUpdatePatch(AlgoliaIndexSettings{}, input)
@@ -156,18 +250,22 @@ func (client *Client) AlgoliaIndexSettingsUpdate(hub_id string, id string, input
 	}
 
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes/%s/settings", hub_id, id)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "AlgoliaIndexSettingsUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) AlgoliaIndexWebhooksGet(hub_id string, id string) ([]Webhook, error) {
+	return client.AlgoliaIndexWebhooksGetWithContext(context.Background(), hub_id, id)
+}
+
+func (client *Client) AlgoliaIndexWebhooksGetWithContext(ctx context.Context, hub_id string, id string) ([]Webhook, error) {
 	endpoint := fmt.Sprintf("/algolia-search/%s/indexes/%s/assigned-content-types", hub_id, id)
 	assignedContentTypes := AssignedContentTypeResults{}
-	err := client.request(http.MethodGet, endpoint, nil, &assignedContentTypes)
+	err := client.request(ctx, "AlgoliaIndexWebhooksGet", http.MethodGet, endpoint, nil, &assignedContentTypes)
 	result := make([]Webhook, len(assignedContentTypes.Items))
 
 	for i, item := range assignedContentTypes.Items {
-		err = client.request(http.MethodGet, item.Links["webhook"].Href, nil, &result[i])
+		result[i], err = item.GetWebhookWithContext(ctx, client)
 		if err != nil {
 			return result, err
 		}
@@ -5,10 +5,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log/slog"
 	"net/http"
 	"net/url"
-	"os"
+	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
@@ -21,16 +23,55 @@ type ClientConfig struct {
 	URL          string
 	AuthURL      string
 	HTTPClient   *http.Client
+
+	// RetryPolicy configures how failed requests are retried. When nil every
+	// request is only tried once. See DefaultRetryPolicy for sensible defaults.
+	RetryPolicy *RetryPolicy
+
+	// RequestsPerSecond limits the number of requests made by the client. The
+	// limit is disabled when set to 0. Burst is the maximum number of requests
+	// which can be made at once, and defaults to 1.
+	RequestsPerSecond float64
+	Burst             int
+
+	// RateLimiter can be set to share a rate limit between multiple clients.
+	// It takes precedence over RequestsPerSecond and Burst.
+	RateLimiter *RateLimiter
+
+	// Logger receives a log record for every request made by the client.
+	// Request and response bodies are only logged at debug level, with
+	// secrets such as webhook secrets redacted. When nil nothing is logged,
+	// unless the AMPLIENCE_DEBUG environment variable is set.
+	Logger *slog.Logger
+
+	// Middlewares are wrapped around every request made by the client, the
+	// first middleware being the outermost one.
+	Middlewares []Middleware
+
+	// DryRun prevents the client from modifying any data. Requests which would
+	// modify data are recorded in the Plan of the client instead of being
+	// sent, and return zero values. Read requests are still sent.
+	DryRun bool
 }
 
 type Client struct {
-	url        string
-	httpClient *http.Client
-	logLevel   int
+	url         string
+	httpClient  *http.Client
+	retryPolicy *RetryPolicy
+	rateLimiter *RateLimiter
+	logger      *slog.Logger
+	doer        Doer
+	plan        *Plan
 }
 
 // NewClient creates a new Client object
 func NewClient(config *ClientConfig) (*Client, error) {
+	return NewClientWithContext(context.Background(), config)
+}
+
+// NewClientWithContext creates a new Client object. The given context is used
+// when fetching OAuth tokens from the AuthURL, so it should outlive the Client.
+func NewClientWithContext(ctx context.Context, config *ClientConfig) (*Client, error) {
 
 	if config.AuthURL == "" {
 		config.AuthURL = "https://auth.adis.ws/oauth/token"
@@ -50,27 +91,73 @@ func NewClient(config *ClientConfig) (*Client, error) {
 	var httpClient *http.Client
 	if config.HTTPClient != nil {
 		httpClient = auth.Client(
-			context.WithValue(oauth2.NoContext, oauth2.HTTPClient, config.HTTPClient))
+			context.WithValue(ctx, oauth2.HTTPClient, config.HTTPClient))
 	} else {
-		httpClient = auth.Client(context.TODO())
+		httpClient = auth.Client(ctx)
 	}
 
 	client := &Client{
-		url:        config.URL,
-		httpClient: httpClient,
+		url:         config.URL,
+		httpClient:  httpClient,
+		retryPolicy: config.RetryPolicy,
+		rateLimiter: config.RateLimiter,
+		logger:      config.Logger,
+	}
+
+	if client.logger == nil {
+		client.logger = defaultLogger()
 	}
 
-	if os.Getenv("AMPLIENCE_DEBUG") != "" {
-		client.logLevel = 1
+	if client.rateLimiter == nil && config.RequestsPerSecond > 0 {
+		client.rateLimiter = NewRateLimiter(config.RequestsPerSecond, config.Burst)
+	}
+
+	client.doer = DoerFunc(client.do)
+	if config.DryRun {
+		client.plan = &Plan{}
+		client.doer = client.plan.middleware(client.doer)
+	}
+	for i := len(config.Middlewares) - 1; i >= 0; i-- {
+		client.doer = config.Middlewares[i](client.doer)
 	}
 	return client, nil
 }
 
-func (client *Client) request(method string, path string, body []byte, output interface{}) error {
+// request performs a request to the API. The operation is the name of the
+// client method making the request, and is exposed to middlewares.
+func (client *Client) request(ctx context.Context, operation string, method string, path string, body []byte, output interface{}) error {
+	_, err := client.requestResponse(ctx, operation, method, path, body, output)
+	return err
+}
+
+// readRequest is the same as request, for requests which do not modify data
+// although they are not sent as a GET, such as searches. These requests are
+// sent in dry run and read-only mode, and are retried like a GET.
+func (client *Client) readRequest(ctx context.Context, operation string, method string, path string, body []byte, output interface{}) error {
+	req, err := client.newRequest(operation, method, path, body, output)
+	if err != nil {
+		return err
+	}
+	req.ReadOnly = true
+	_, err = client.doer.Do(ctx, req)
+	return err
+}
+
+// requestResponse is the same as request, but also returns the Response for
+// callers which need its status code or headers.
+func (client *Client) requestResponse(ctx context.Context, operation string, method string, path string, body []byte, output interface{}) (*Response, error) {
+	req, err := client.newRequest(operation, method, path, body, output)
+	if err != nil {
+		return nil, err
+	}
+	return client.doer.Do(ctx, req)
+}
+
+func (client *Client) newRequest(operation string, method string, path string, body []byte, output interface{}) (*Request, error) {
 
 	raw_url, err := url.Parse(path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var url string
@@ -80,59 +167,118 @@ func (client *Client) request(method string, path string, body []byte, output in
 		url = fmt.Sprintf("%s%s", client.url, path)
 	}
 
-	buf := bytes.NewBuffer(body)
-
-	ctx := context.Background()
-	req, err := http.NewRequestWithContext(ctx, method, url, buf)
-
-	req.Header.Set("content-type", "application/json")
-
-	if client.logLevel > 0 {
-		logRequest(req)
-	}
-
-	resp, err := client.httpClient.Do(req)
-
-	if resp != nil && client.logLevel > 0 {
-		logResponse(resp)
+	req := &Request{
+		Operation: operation,
+		Method:    method,
+		Endpoint:  path,
+		URL:       url,
+		Body:      body,
+		Header:    http.Header{},
+		Output:    output,
 	}
+	return req, nil
+}
 
+// do is the Doer at the end of the middleware chain. It sends the request and
+// decodes the response into the output of the request, or into an
+// ErrorResponse.
+func (client *Client) do(ctx context.Context, req *Request) (*Response, error) {
+	resp, attempts, err := client.send(ctx, req)
 	if err != nil {
-		return err
+		return &Response{Attempts: attempts}, err
 	}
 
 	defer resp.Body.Close()
 
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Attempts:   attempts,
+	}
+
 	switch {
 	case resp.StatusCode >= 200 && resp.StatusCode < 204:
-		err = json.NewDecoder(resp.Body).Decode(output)
-		if err != nil {
-			return err
+		// An empty body, e.g. of a 202 Accepted, leaves the output unchanged
+		err = json.NewDecoder(resp.Body).Decode(req.Output)
+		if err != nil && err != io.EOF {
+			return response, err
 		}
 	case resp.StatusCode == 204:
-		return nil
+		return response, nil
 	case resp.StatusCode >= 400:
-		newErr := ErrorResponse{}
+		newErr := ErrorResponse{StatusCode: resp.StatusCode}
 
-		bodyBytes, _ := ioutil.ReadAll(resp.Body)
+		bodyBytes, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			newErr.Inner = err
+			return response, &newErr
+		}
 
-		if err = json.NewDecoder(bytes.NewBuffer(bodyBytes)).Decode(&newErr); err != nil {
-			return err
+		if err = json.Unmarshal(bodyBytes, &newErr); err != nil {
+			// The body is not always JSON, e.g. when a proxy returns a 502
+			if body := bytes.TrimSpace(bodyBytes); len(body) > 0 {
+				newErr.Inner = fmt.Errorf("unexpected error response: %s", body)
+			}
+			return response, &newErr
 		}
 		if len(newErr.Errors) == 0 {
 			// The API sometimes returns just `{message}` instead of `{errors: [{message}]}`,
 			// so we try again for those cases.
 			errorObject := ErrorObject{}
-			if err = json.NewDecoder(bytes.NewBuffer(bodyBytes)).Decode(&errorObject); err != nil {
-				return err
+			if err = json.Unmarshal(bodyBytes, &errorObject); err == nil && errorObject.Message != "" {
+				newErr.Errors = []ErrorObject{errorObject}
 			}
-			newErr.Errors = []ErrorObject{errorObject}
 		}
-		newErr.StatusCode = resp.StatusCode
-		newErr.Inner = err
-		return &newErr
+		return response, &newErr
 	}
 
-	return nil
+	return response, nil
 
 }
+
+// send performs the HTTP request, retrying it according to the retry policy of
+// the client. The request body is re-created for every attempt, and every
+// attempt counts against the rate limit.
+func (client *Client) send(ctx context.Context, r *Request) (*http.Response, int, error) {
+	requestID := newRequestID()
+	for attempt := 1; ; attempt++ {
+		if err := client.rateLimiter.Wait(ctx); err != nil {
+			return nil, attempt - 1, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, r.Method, r.URL, bytes.NewReader(r.Body))
+		if err != nil {
+			return nil, attempt - 1, err
+		}
+
+		for name, values := range r.Header {
+			req.Header[name] = values
+		}
+		req.Header.Set("content-type", "application/json")
+
+		client.logRequest(ctx, req, requestID, attempt, r.Body)
+		start := time.Now()
+		resp, err := client.httpClient.Do(req)
+		client.logResponse(ctx, req, resp, err, requestID, attempt, time.Since(start))
+
+		if attempt >= client.retryPolicy.maxAttempts() || ctx.Err() != nil ||
+			!client.retryPolicy.shouldRetry(r, resp, err) {
+			return resp, attempt, err
+		}
+
+		delay := client.retryPolicy.delay(attempt, resp)
+		client.logRetry(ctx, req, requestID, attempt, delay)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, attempt, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
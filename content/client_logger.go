@@ -1,35 +1,173 @@
 package content
 
 import (
-	"log"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
 	"net/http"
-	"net/http/httputil"
+	"os"
+	"time"
 )
 
-const logRequestTemplate = `DEBUG:
----[ REQUEST ]--------------------------------------------------------
-%s
-----------------------------------------------------------------------
-`
+const redacted = "REDACTED"
 
-const logResponseTemplate = `DEBUG:
----[ RESPONSE ]-------------------------------------------------------
-%s
-----------------------------------------------------------------------
-`
+// redactedHeaders are never logged with their actual value.
+var redactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie"}
 
-func logRequest(r *http.Request) {
-	body, err := httputil.DumpRequestOut(r, true)
-	if err != nil {
+// redactedFields are JSON fields whose string values are never logged, such
+// as Webhook.Secret and AmplienceDamSettings.ApiSecret.
+var redactedFields = map[string]bool{
+	"secret":        true,
+	"API_SECRET":    true,
+	"client_secret": true,
+	"access_token":  true,
+}
+
+// defaultLogger returns the logger used when no Logger is configured. For
+// backwards compatibility debug logging is enabled with the AMPLIENCE_DEBUG
+// environment variable.
+func defaultLogger() *slog.Logger {
+	if os.Getenv("AMPLIENCE_DEBUG") == "" {
+		return nil
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// newRequestID returns a random id which is logged with every attempt of a
+// request, to correlate the log lines.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+func (client *Client) logRequest(ctx context.Context, r *http.Request, requestID string, attempt int, body []byte) {
+	if client.logger == nil || !client.logger.Enabled(ctx, slog.LevelDebug) {
 		return
 	}
-	log.Printf(logRequestTemplate, body)
+	client.logger.LogAttrs(ctx, slog.LevelDebug, "amplience request",
+		slog.String("request_id", requestID),
+		slog.String("method", r.Method),
+		slog.String("url", r.URL.String()),
+		slog.Int("attempt", attempt),
+		slog.Any("headers", redactHeaders(r.Header)),
+		slog.String("body", string(redactBody(body))),
+	)
 }
 
-func logResponse(r *http.Response) {
-	body, err := httputil.DumpResponse(r, true)
+func (client *Client) logResponse(ctx context.Context, r *http.Request, resp *http.Response, err error, requestID string, attempt int, duration time.Duration) {
+	if client.logger == nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("request_id", requestID),
+		slog.String("method", r.Method),
+		slog.String("url", r.URL.String()),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+
 	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+		client.logger.LogAttrs(ctx, slog.LevelError, "amplience request failed", attrs...)
+		return
+	}
+
+	level := slog.LevelDebug
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	if !client.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs = append(attrs, slog.Int("status", resp.StatusCode))
+	if client.logger.Enabled(ctx, slog.LevelDebug) {
+		// Read the body so it can be logged, and replace it so it can still be
+		// decoded afterwards.
+		body, readErr := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if readErr == nil {
+			attrs = append(attrs,
+				slog.Any("headers", redactHeaders(resp.Header)),
+				slog.String("body", string(redactBody(body))))
+		}
+	}
+	client.logger.LogAttrs(ctx, level, "amplience response", attrs...)
+}
+
+func (client *Client) logRetry(ctx context.Context, r *http.Request, requestID string, attempt int, delay time.Duration) {
+	if client.logger == nil {
 		return
 	}
-	log.Printf(logResponseTemplate, body)
+	client.logger.LogAttrs(ctx, slog.LevelInfo, "retrying amplience request",
+		slog.String("request_id", requestID),
+		slog.String("method", r.Method),
+		slog.String("url", r.URL.String()),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+	)
+}
+
+// redactHeaders returns a copy of the headers with the values of sensitive
+// headers replaced.
+func redactHeaders(headers http.Header) http.Header {
+	result := headers.Clone()
+	for _, name := range redactedHeaders {
+		if result.Get(name) != "" {
+			result.Set(name, redacted)
+		}
+	}
+	return result
+}
+
+// redactBody replaces secrets in a JSON body. Bodies which are not valid JSON
+// are returned as is.
+func redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return body
+	}
+
+	result, err := json.Marshal(redactValue(data))
+	if err != nil {
+		return body
+	}
+	return result
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if _, ok := item.(string); ok && redactedFields[key] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(item)
+		}
+		// The value of a WebhookHeader is secret when its `secret` flag is set
+		if secret, ok := v["secret"].(bool); ok && secret {
+			if _, ok := v["value"]; ok {
+				v["value"] = redacted
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
 }
@@ -0,0 +1,64 @@
+package content
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactBody(t *testing.T) {
+	body, err := json.Marshal(WebhookInput{
+		Label:  "webhook",
+		Secret: "webhook-secret",
+		Headers: []WebhookHeader{
+			{Key: "X-Public", Value: "public"},
+			{Key: "X-Token", Value: "header-secret", Secret: true},
+		},
+	})
+	assert.NoError(t, err)
+
+	result := string(redactBody(body))
+	assert.NotContains(t, result, "webhook-secret")
+	assert.NotContains(t, result, "header-secret")
+	assert.Contains(t, result, "public")
+
+	body, err = json.Marshal(HubUpdateInput{Settings: &Settings{Publishing: &PublishingSettings{
+		Platforms: &PlatformSettings{AmplienceDam: &AmplienceDamSettings{ApiKey: "key", ApiSecret: "dam-secret"}},
+	}}})
+	assert.NoError(t, err)
+	assert.NotContains(t, string(redactBody(body)), "dam-secret")
+
+	assert.Equal(t, "not json", string(redactBody([]byte("not json"))))
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	// The token of the client is added by the transport, after logging, so
+	// a header is added to the request itself to check the redaction.
+	authorize := func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("Authorization", "Bearer request-token")
+			return next.Do(ctx, req)
+		})
+	}
+	config := ClientConfig{Logger: logger, Middlewares: []Middleware{authorize}}
+	client := newTestClient(t, config, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=cookie-secret")
+		w.Write([]byte(`{"id": "webhook-id", "secret": "webhook-secret"}`))
+	})
+
+	_, err := client.WebhookCreate("hub-id", WebhookInput{Secret: "webhook-secret"})
+	assert.NoError(t, err)
+	assert.NotContains(t, buf.String(), "webhook-secret")
+	assert.NotContains(t, buf.String(), "request-token")
+	assert.NotContains(t, buf.String(), "cookie-secret")
+	assert.Contains(t, buf.String(), `"Authorization":["REDACTED"]`)
+	assert.Contains(t, buf.String(), `"Set-Cookie":["REDACTED"]`)
+	assert.Contains(t, buf.String(), `"status":200`)
+}
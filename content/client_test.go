@@ -0,0 +1,161 @@
+package content
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestClient returns a Client which talks to a test server using the given
+// handler for all API calls.
+func newTestClient(t *testing.T, config ClientConfig, handler http.HandlerFunc) *Client {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(`{"access_token": "token", "token_type": "bearer", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/", handler)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	config.URL = server.URL
+	config.AuthURL = server.URL + "/oauth/token"
+	client, err := NewClient(&config)
+	assert.NoError(t, err)
+	return client
+}
+
+func testRetryPolicy() *RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MinBackoff = time.Millisecond
+	policy.Jitter = 0
+	return policy
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, ClientConfig{RetryPolicy: testRetryPolicy()}, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"errors": [{"message": "bad gateway"}]}`))
+			return
+		}
+		w.Write([]byte(`{"id": "hub-id"}`))
+	})
+
+	hub, err := client.HubGet("hub-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "hub-id", hub.ID)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestRetryNonIdempotentRequest(t *testing.T) {
+	var calls int32
+	var mu sync.Mutex
+	var bodies []string
+	client := newTestClient(t, ClientConfig{RetryPolicy: testRetryPolicy()}, func(w http.ResponseWriter, r *http.Request) {
+		buf, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		bodies = append(bodies, string(buf))
+		mu.Unlock()
+
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"errors": [{"message": "rate limited"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte(`{"errors": [{"message": "bad gateway"}]}`))
+		}
+	})
+
+	_, err := client.ContentItemCreate("repository-id", ContentItemInput{Label: "retry"})
+	assert.Error(t, err)
+	assert.Equal(t, int32(2), calls)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	d, ok := parseRetryAfter("12")
+	assert.True(t, ok)
+	assert.Equal(t, 12*time.Second, d)
+
+	d, ok = parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	_, ok = parseRetryAfter("soon")
+	assert.False(t, ok)
+}
+
+func TestMiddlewares(t *testing.T) {
+	var seen []string
+	var status int
+	headers := func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, req *Request) (*Response, error) {
+			req.Header.Set("X-Custom", "custom")
+			return next.Do(ctx, req)
+		})
+	}
+	recorder := func(next Doer) Doer {
+		return DoerFunc(func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next.Do(ctx, req)
+			seen = append(seen, req.Method+" "+req.Endpoint)
+			status = resp.StatusCode
+			assert.True(t, IsNotFound(err))
+			return resp, err
+		})
+	}
+
+	client := newTestClient(t, ClientConfig{Middlewares: []Middleware{ReadOnly, headers, recorder}}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "custom", r.Header.Get("X-Custom"))
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.HubGet("hub-id")
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, []string{"GET /hubs/hub-id"}, seen)
+	assert.Equal(t, http.StatusNotFound, status)
+
+	err = client.WebhookDelete("hub-id", "webhook-id")
+	assert.ErrorIs(t, err, ErrReadOnly)
+	assert.Len(t, seen, 1)
+}
+
+func TestDryRun(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, ClientConfig{DryRun: true}, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, http.MethodGet, r.Method)
+		w.Write([]byte(`{"id": "hub-id", "name": "hub"}`))
+	})
+
+	hub, err := client.HubGet("hub-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "hub", hub.Name)
+
+	_, err = client.HubPatch("hub-id", HubUpdateInput{Name: "renamed"})
+	assert.NoError(t, err)
+	assert.NoError(t, client.WebhookDelete("hub-id", "webhook-id"))
+	assert.Equal(t, int32(1), calls)
+
+	requests := client.Plan().Requests()
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "HubPatch", requests[0].Operation)
+	assert.Equal(t, http.MethodPatch, requests[0].Method)
+	assert.Equal(t, "/hubs/hub-id", requests[0].Endpoint)
+	assert.Contains(t, string(requests[0].Body), `"name":"renamed"`)
+	assert.Equal(t, "WebhookDelete", requests[1].Operation)
+}
@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -71,6 +73,32 @@ func (r *ContentItemResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ContentItemResults) Next(client *Client) (ContentItemResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ContentItemResults) NextWithContext(ctx context.Context, client *Client) (ContentItemResults, error) {
+	result := ContentItemResults{}
+	err := client.followRelation(ctx, "ContentItemResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ContentItemResults) Prev(client *Client) (ContentItemResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ContentItemResults) PrevWithContext(ctx context.Context, client *Client) (ContentItemResults, error) {
+	result := ContentItemResults{}
+	err := client.followRelation(ctx, "ContentItemResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 // ContentItemResults is returned by the ContentItemList func
 type ContentItemVersionHistoryResults struct {
 	Links map[string]Link `json:"_links"`
@@ -94,30 +122,87 @@ func (r *ContentItemVersionHistoryResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ContentItemVersionHistoryResults) Next(client *Client) (ContentItemVersionHistoryResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ContentItemVersionHistoryResults) NextWithContext(ctx context.Context, client *Client) (ContentItemVersionHistoryResults, error) {
+	result := ContentItemVersionHistoryResults{}
+	err := client.followRelation(ctx, "ContentItemVersionHistoryResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ContentItemVersionHistoryResults) Prev(client *Client) (ContentItemVersionHistoryResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ContentItemVersionHistoryResults) PrevWithContext(ctx context.Context, client *Client) (ContentItemVersionHistoryResults, error) {
+	result := ContentItemVersionHistoryResults{}
+	err := client.followRelation(ctx, "ContentItemVersionHistoryResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
+// GetRepository returns the Content Repository which contains the item
+func (r *ContentItem) GetRepository(client *Client) (ContentRepository, error) {
+	return r.GetRepositoryWithContext(context.Background(), client)
+}
+
+// GetRepositoryWithContext is the same as GetRepository with a custom context
+func (r *ContentItem) GetRepositoryWithContext(ctx context.Context, client *Client) (ContentRepository, error) {
+	result := ContentRepository{}
+	err := client.followRelation(ctx, "ContentItem.GetRepository", r.Links, "content-repository", &result)
+	return result, err
+}
+
 // ContentItemCreate creates a new content item
 func (client *Client) ContentItemCreate(repositoryID string, input ContentItemInput) (ContentItem, error) {
+	return client.ContentItemCreateWithContext(context.Background(), repositoryID, input)
+}
+
+// ContentItemCreateWithContext is the same as ContentItemCreate with a custom context
+func (client *Client) ContentItemCreateWithContext(ctx context.Context, repositoryID string, input ContentItemInput) (ContentItem, error) {
 	result := ContentItem{}
 	body, err := json.Marshal(input)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/content-repositories/%s/content-items", repositoryID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentItemCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 // ContentItemGet returns the content item with the given id
 func (client *Client) ContentItemGet(id string) (ContentItem, error) {
+	return client.ContentItemGetWithContext(context.Background(), id)
+}
+
+// ContentItemGetWithContext is the same as ContentItemGet with a custom context
+func (client *Client) ContentItemGetWithContext(ctx context.Context, id string) (ContentItem, error) {
 	endpoint := fmt.Sprintf("/content-items/%s", id)
 	result := ContentItem{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentItemGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
-// ContentItemUpdate updates a Content Item. Please note that deliveryKey can
-// only be set when Content Delivery 2 is enabled.
+// ContentItemUpdate updates a Content Item. Please note that a delivery key
+// (see ContentItemInput.SetDeliveryKey) can only be set when Content Delivery 2
+// is enabled.
+//
+// The update is based on the Version of current. When the item was modified
+// since, the update fails with an error for which IsConflict returns true.
 func (client *Client) ContentItemUpdate(current ContentItem, input ContentItemInput) (ContentItem, error) {
+	return client.ContentItemUpdateWithContext(context.Background(), current, input)
+}
+
+// ContentItemUpdateWithContext is the same as ContentItemUpdate with a custom context
+func (client *Client) ContentItemUpdateWithContext(ctx context.Context, current ContentItem, input ContentItemInput) (ContentItem, error) {
 	result := ContentItem{}
 
 	body, err := createUpdatePatch(
@@ -129,84 +214,308 @@ func (client *Client) ContentItemUpdate(current ContentItem, input ContentItemIn
 		},
 		input)
 
+	if err != nil {
+		return result, err
+	}
+
 	if body == nil {
 		return current, nil
 	}
 
+	// Send the version the changes are based on, so the update is rejected
+	// when the item was modified in the meantime.
+	body, err = addPatchVersion(body, current.Version)
 	if err != nil {
 		return result, err
 	}
 
 	endpoint := fmt.Sprintf("/content-items/%s", current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "ContentItemUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
+// defaultUpdateAttempts is the number of attempts of the updates done on
+// behalf of the caller, such as ContentItemMove and ContentItemSetDeliveryKey.
+const defaultUpdateAttempts = 3
+
+// ContentItemUpdateWithRetry fetches the content item, applies the mutate
+// function to its current values and updates it. When the item is modified by
+// someone else in the meantime, this is repeated with the latest version of the
+// item, at most maxAttempts times in total. The Body of the input passed to
+// mutate is never nil.
+func (client *Client) ContentItemUpdateWithRetry(id string, maxAttempts int, mutate func(input *ContentItemInput) error) (ContentItem, error) {
+	return client.ContentItemUpdateWithRetryWithContext(context.Background(), id, maxAttempts, mutate)
+}
+
+// ContentItemUpdateWithRetryWithContext is the same as ContentItemUpdateWithRetry with a custom context
+func (client *Client) ContentItemUpdateWithRetryWithContext(ctx context.Context, id string, maxAttempts int, mutate func(input *ContentItemInput) error) (ContentItem, error) {
+	for attempt := 1; ; attempt++ {
+		current, err := client.ContentItemGetWithContext(ctx, id)
+		if err != nil {
+			return current, err
+		}
+
+		// Copy the body, since the current body is needed to compute the patch
+		body, err := copyBody(current.Body)
+		if err != nil {
+			return current, err
+		}
+		if body == nil {
+			body = map[string]interface{}{}
+		}
+
+		input := ContentItemInput{
+			Body:     body,
+			Label:    current.Label,
+			FolderID: current.FolderID,
+			Locale:   current.Locale,
+		}
+		if err := mutate(&input); err != nil {
+			return current, err
+		}
+
+		result, err := client.ContentItemUpdateWithContext(ctx, current, input)
+		if err == nil || !IsConflict(err) || attempt >= maxAttempts {
+			return result, err
+		}
+	}
+}
+
 // ContentItemList lists all of the Content Items within the given Content
 // Repository
 func (client *Client) ContentItemList(repositoryID string, parameters ContentItemPaginationParameters) (ContentItemResults, error) {
+	return client.ContentItemListWithContext(context.Background(), repositoryID, parameters)
+}
+
+// ContentItemListWithContext is the same as ContentItemList with a custom context
+func (client *Client) ContentItemListWithContext(ctx context.Context, repositoryID string, parameters ContentItemPaginationParameters) (ContentItemResults, error) {
 	result := ContentItemResults{}
 	endpoint := fmt.Sprintf("/content-repositories/%s/content-items?%s", repositoryID, ContentItemPaginationQueryString(parameters))
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentItemList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentItemGetAll(hubID string, status ContentStatus) ([]ContentItem, error) {
+	return client.ContentItemGetAllWithContext(context.Background(), hubID, status)
+}
+
+func (client *Client) ContentItemGetAllWithContext(ctx context.Context, hubID string, status ContentStatus) ([]ContentItem, error) {
 	parameters := ContentItemPaginationParameters{}
 	parameters.Status = status
+	return Collect(client.ContentItemIterateWithContext(ctx, hubID, parameters))
+}
 
-	response, err := client.ContentItemList(hubID, parameters)
+// ContentItemIterate returns an iterator over all Content Items within the
+// given Content Repository. Pages are fetched as needed, so iteration can be
+// stopped early. The Page parameter is ignored.
+func (client *Client) ContentItemIterate(repositoryID string, parameters ContentItemPaginationParameters) iter.Seq2[ContentItem, error] {
+	return client.ContentItemIterateWithContext(context.Background(), repositoryID, parameters)
+}
 
-	var result []ContentItem
-	result = append(result, response.Items...)
+// ContentItemIterateWithContext is the same as ContentItemIterate with a custom context
+func (client *Client) ContentItemIterateWithContext(ctx context.Context, repositoryID string, parameters ContentItemPaginationParameters) iter.Seq2[ContentItem, error] {
+	return Iterate(func(page int) ([]ContentItem, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.ContentItemListWithContext(ctx, repositoryID, parameters)
+		return response.Items, response.Page, err
+	})
+}
 
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.ContentItemList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
+type archiveOptions struct {
+	conflictRetries int
+	recursive       bool
+}
+
+// ArchiveOption configures how content items are archived and unarchived.
+type ArchiveOption func(*archiveOptions)
+
+// ArchiveRetryOnConflict refetches the content item and retries with its
+// current version, at most retries times, when the given version is outdated.
+func ArchiveRetryOnConflict(retries int) ArchiveOption {
+	return func(o *archiveOptions) {
+		o.conflictRetries = retries
 	}
+}
 
-	return result, err
+// ArchiveRecursive also archives or unarchives all hierarchy children of the
+// content item.
+func ArchiveRecursive() ArchiveOption {
+	return func(o *archiveOptions) {
+		o.recursive = true
+	}
 }
 
 // ContentItemArchive archives a content item
-func (client *Client) ContentItemArchive(id string, version int) (ContentItem, error) {
-	result := ContentItem{}
-	endpoint := fmt.Sprintf("/content-types/%s/archive", id)
+func (client *Client) ContentItemArchive(id string, version int, opts ...ArchiveOption) (ContentItem, error) {
+	return client.ContentItemArchiveWithContext(context.Background(), id, version, opts...)
+}
 
-	body, err := json.Marshal(ArchiveInput{Version: version})
-	if err != nil {
-		return result, err
+// ContentItemArchiveWithContext is the same as ContentItemArchive with a custom context
+func (client *Client) ContentItemArchiveWithContext(ctx context.Context, id string, version int, opts ...ArchiveOption) (ContentItem, error) {
+	options := archiveOptions{}
+	for _, opt := range opts {
+		opt(&options)
 	}
 
-	err = client.request(http.MethodPost, endpoint, body, &result)
-	return result, err
+	if options.recursive {
+		// Collect the children first, since archiving them changes the pages
+		children, err := Collect(client.ContentItemChildrenIterateWithContext(ctx, id))
+		if err != nil {
+			return ContentItem{}, err
+		}
+		for _, child := range children {
+			if child.Status == string(StatusArchived) {
+				continue
+			}
+			if _, err := client.ContentItemArchiveWithContext(ctx, child.ID, child.Version, opts...); err != nil {
+				return ContentItem{}, err
+			}
+		}
+	}
+
+	return client.contentItemArchiveAction(ctx, "ContentItemArchive", "archive", id, version, options)
 }
 
 // ContentItemUnarchive unarchives a content item
-func (client *Client) ContentItemUnarchive(id string, version int) (ContentItem, error) {
-	result := ContentItem{}
-	endpoint := fmt.Sprintf("/content-types/%s/unarchive", id)
+func (client *Client) ContentItemUnarchive(id string, version int, opts ...ArchiveOption) (ContentItem, error) {
+	return client.ContentItemUnarchiveWithContext(context.Background(), id, version, opts...)
+}
+
+// ContentItemUnarchiveWithContext is the same as ContentItemUnarchive with a custom context
+func (client *Client) ContentItemUnarchiveWithContext(ctx context.Context, id string, version int, opts ...ArchiveOption) (ContentItem, error) {
+	options := archiveOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
 
-	body, err := json.Marshal(ArchiveInput{Version: version})
+	result, err := client.contentItemArchiveAction(ctx, "ContentItemUnarchive", "unarchive", id, version, options)
+	if err != nil || !options.recursive {
+		return result, err
+	}
+
+	children, err := Collect(client.ContentItemChildrenIterateWithContext(ctx, id))
 	if err != nil {
 		return result, err
 	}
+	for _, child := range children {
+		if child.Status != string(StatusArchived) {
+			continue
+		}
+		if _, err := client.ContentItemUnarchiveWithContext(ctx, child.ID, child.Version, opts...); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
 
-	err = client.request(http.MethodPost, endpoint, body, &result)
-	return result, err
+// contentItemArchiveAction posts an archive or unarchive action for a single
+// content item, refetching the version on a conflict if requested.
+func (client *Client) contentItemArchiveAction(ctx context.Context, operation string, action string, id string, version int, options archiveOptions) (ContentItem, error) {
+	endpoint := fmt.Sprintf("/content-items/%s/%s", id, action)
+
+	for attempt := 0; ; attempt++ {
+		result := ContentItem{}
+		body, err := json.Marshal(ArchiveInput{Version: version})
+		if err != nil {
+			return result, err
+		}
+
+		err = client.request(ctx, operation, http.MethodPost, endpoint, body, &result)
+		if err == nil || !IsConflict(err) || attempt >= options.conflictRetries {
+			return result, err
+		}
+
+		current, getErr := client.ContentItemGetWithContext(ctx, id)
+		if getErr != nil {
+			return result, err
+		}
+		version = current.Version
+	}
+}
+
+// ContentItemDelete permanently deletes a content item. Only archived content
+// items can be deleted.
+func (client *Client) ContentItemDelete(id string) error {
+	return client.ContentItemDeleteWithContext(context.Background(), id)
+}
+
+// ContentItemDeleteWithContext is the same as ContentItemDelete with a custom context
+func (client *Client) ContentItemDeleteWithContext(ctx context.Context, id string) error {
+	endpoint := fmt.Sprintf("/content-items/%s", id)
+	return client.request(ctx, "ContentItemDelete", http.MethodDelete, endpoint, nil, nil)
 }
 
 // ContentItemListHistory list history of this item
 func (client *Client) ContentItemListHistory(id string, version int) (ContentItemVersionHistoryResults, error) {
+	return client.ContentItemListHistoryWithContext(context.Background(), id, version)
+}
+
+// ContentItemListHistoryWithContext is the same as ContentItemListHistory with a custom context
+func (client *Client) ContentItemListHistoryWithContext(ctx context.Context, id string, version int) (ContentItemVersionHistoryResults, error) {
 	endpoint := fmt.Sprintf("/content-items/%s/versions/%d/history", id, version)
 	result := ContentItemVersionHistoryResults{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentItemListHistory", http.MethodGet, endpoint, nil, &result)
 	return result, err
 
 }
+
+// ContentItemGetVersion returns the content item as it was at the given
+// version.
+func (client *Client) ContentItemGetVersion(id string, version int) (ContentItem, error) {
+	return client.ContentItemGetVersionWithContext(context.Background(), id, version)
+}
+
+// ContentItemGetVersionWithContext is the same as ContentItemGetVersion with a custom context
+func (client *Client) ContentItemGetVersionWithContext(ctx context.Context, id string, version int) (ContentItem, error) {
+	endpoint := fmt.Sprintf("/content-items/%s/versions/%d", id, version)
+	result := ContentItem{}
+
+	err := client.request(ctx, "ContentItemGetVersion", http.MethodGet, endpoint, nil, &result)
+	return result, err
+}
+
+// ContentItemDiffVersions returns the JSON Patch which changes the body of the
+// content item at version from into the body at version to.
+func (client *Client) ContentItemDiffVersions(id string, from int, to int) ([]PatchOperation, error) {
+	return client.ContentItemDiffVersionsWithContext(context.Background(), id, from, to)
+}
+
+// ContentItemDiffVersionsWithContext is the same as ContentItemDiffVersions with a custom context
+func (client *Client) ContentItemDiffVersionsWithContext(ctx context.Context, id string, from int, to int) ([]PatchOperation, error) {
+	src, err := client.ContentItemGetVersionWithContext(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := client.ContentItemGetVersionWithContext(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+	return DiffJSON(src.Body, dst.Body)
+}
+
+// ContentItemRestoreVersion updates the content item to the body and label it
+// had at the given version. This creates a new version of the item.
+//
+// Like ContentItemUpdate the update is based on the Version of current, so it
+// fails with an error for which IsConflict returns true when the item was
+// modified since current was fetched.
+func (client *Client) ContentItemRestoreVersion(current ContentItem, version int) (ContentItem, error) {
+	return client.ContentItemRestoreVersionWithContext(context.Background(), current, version)
+}
+
+// ContentItemRestoreVersionWithContext is the same as ContentItemRestoreVersion with a custom context
+func (client *Client) ContentItemRestoreVersionWithContext(ctx context.Context, current ContentItem, version int) (ContentItem, error) {
+	previous, err := client.ContentItemGetVersionWithContext(ctx, current.ID, version)
+	if err != nil {
+		return current, err
+	}
+
+	return client.ContentItemUpdateWithContext(ctx, current, ContentItemInput{
+		Body:     previous.Body,
+		Label:    previous.Label,
+		FolderID: current.FolderID,
+		Locale:   current.Locale,
+	})
+}
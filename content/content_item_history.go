@@ -0,0 +1,164 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryReport is the changelog of a content item, see
+// Client.ContentItemHistoryReport.
+type HistoryReport struct {
+	ContentItemID string                 `json:"contentItemId"`
+	Label         string                 `json:"label"`
+	Versions      []HistoryReportVersion `json:"versions"`
+}
+
+// HistoryReportVersion contains the history events of a version of a content
+// item, and the changes of its body compared to the previous version.
+type HistoryReportVersion struct {
+	Version int `json:"version"`
+	// Changes is the JSON Patch from the body of the previous version to this
+	// version. It is empty for the first version.
+	Changes []PatchOperation            `json:"changes"`
+	Events  []ContentItemVersionHistory `json:"events"`
+}
+
+// ChangedFields returns the JSON pointers of the body fields which changed
+// in this version.
+func (v HistoryReportVersion) ChangedFields() []string {
+	result := make([]string, len(v.Changes))
+	for i, change := range v.Changes {
+		result[i] = change.Path
+	}
+	return result
+}
+
+// ContentItemHistoryReport walks all pages of the version history of a content
+// item, and returns who did what in each version. Every version of the item is
+// fetched to compare the bodies, so this can be slow for items with many
+// versions.
+func (client *Client) ContentItemHistoryReport(id string) (HistoryReport, error) {
+	return client.ContentItemHistoryReportWithContext(context.Background(), id)
+}
+
+// ContentItemHistoryReportWithContext is the same as ContentItemHistoryReport with a custom context
+func (client *Client) ContentItemHistoryReportWithContext(ctx context.Context, id string) (HistoryReport, error) {
+	report := HistoryReport{ContentItemID: id}
+	current, err := client.ContentItemGetWithContext(ctx, id)
+	if err != nil {
+		return report, err
+	}
+	report.Label = current.Label
+
+	events := map[int][]ContentItemVersionHistory{}
+	results, err := client.ContentItemListHistoryWithContext(ctx, id, current.Version)
+	for err == nil {
+		for _, event := range results.Items {
+			events[event.Version] = append(events[event.Version], event)
+		}
+		results, err = results.NextWithContext(ctx, client)
+	}
+	if !errors.Is(err, ErrLinkNotFound) {
+		return report, err
+	}
+
+	var previous map[string]interface{}
+	for version := 1; version <= current.Version; version++ {
+		item := current
+		if version < current.Version {
+			if item, err = client.ContentItemGetVersionWithContext(ctx, id, version); err != nil {
+				return report, err
+			}
+		}
+
+		entry := HistoryReportVersion{Version: version, Events: events[version]}
+		sort.SliceStable(entry.Events, func(i, j int) bool {
+			a, b := entry.Events[i].CreatedDate, entry.Events[j].CreatedDate
+			return a != nil && b != nil && a.Before(*b)
+		})
+		if version > 1 {
+			if entry.Changes, err = DiffJSON(previous, item.Body); err != nil {
+				return report, err
+			}
+		}
+		report.Versions = append(report.Versions, entry)
+		previous = item.Body
+	}
+	return report, nil
+}
+
+// WriteJSON writes the report as indented JSON
+func (r HistoryReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteMarkdown writes the report as a Markdown changelog, newest version
+// first.
+func (r HistoryReport) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+	title := r.Label
+	if title == "" {
+		title = r.ContentItemID
+	}
+	fmt.Fprintf(&b, "# History of %s\n", title)
+
+	for i := len(r.Versions) - 1; i >= 0; i-- {
+		version := r.Versions[i]
+		fmt.Fprintf(&b, "\n## Version %d\n\n", version.Version)
+
+		for _, event := range version.Events {
+			date := "unknown date"
+			if event.CreatedDate != nil {
+				date = event.CreatedDate.UTC().Format(time.RFC3339)
+			}
+			fmt.Fprintf(&b, "- %s by %s: %s\n", date, valueOr(event.CreatedBy, "unknown user"), describeAction(event.Action))
+		}
+		if len(version.Events) == 0 {
+			b.WriteString("- No history events\n")
+		}
+
+		if len(version.Changes) > 0 {
+			b.WriteString("\nChanged fields:\n\n")
+			for _, change := range version.Changes {
+				fmt.Fprintf(&b, "- `%s` %s\n", change.Path, describeOperation(change.Op))
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func describeAction(action ContentItemAction) string {
+	if len(action.Data) == 0 {
+		return action.Code
+	}
+	data, _ := json.Marshal(action.Data)
+	return fmt.Sprintf("%s `%s`", action.Code, data)
+}
+
+func describeOperation(op string) string {
+	switch op {
+	case "add":
+		return "added"
+	case "remove":
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+func valueOr(value string, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,66 @@
+package content
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentItemHistoryReport(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content-items/item-id":
+			w.Write([]byte(`{"id": "item-id", "version": 3, "label": "Banner", "body": {"title": "final", "image": "b.png"}}`))
+		case "/content-items/item-id/versions/1":
+			w.Write([]byte(`{"id": "item-id", "version": 1, "body": {"title": "draft"}}`))
+		case "/content-items/item-id/versions/2":
+			w.Write([]byte(`{"id": "item-id", "version": 2, "body": {"title": "final"}}`))
+		case "/content-items/item-id/versions/3/history":
+			if r.URL.Query().Get("page") == "" {
+				w.Write([]byte(`{
+					"_embedded": {"content-item-version-history": [
+						{"version": 3, "createdBy": "bob", "createdDate": "2026-01-03T10:00:00Z", "action": {"code": "UPDATED"}},
+						{"version": 2, "createdBy": "bob", "createdDate": "2026-01-02T11:00:00Z", "action": {"code": "PUBLISHED"}}
+					]},
+					"_links": {"next": {"href": "/content-items/item-id/versions/3/history?page=1"}}
+				}`))
+				return
+			}
+			w.Write([]byte(`{
+				"_embedded": {"content-item-version-history": [
+					{"version": 2, "createdBy": "alice", "createdDate": "2026-01-02T10:00:00Z", "action": {"code": "UPDATED"}},
+					{"version": 1, "createdBy": "alice", "createdDate": "2026-01-01T10:00:00Z", "action": {"code": "CREATED"}}
+				]},
+				"_links": {}
+			}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	report, err := client.ContentItemHistoryReport("item-id")
+	assert.NoError(t, err)
+	assert.Len(t, report.Versions, 3)
+	assert.Empty(t, report.Versions[0].Changes)
+	assert.Equal(t, []string{"/title"}, report.Versions[1].ChangedFields())
+	assert.Equal(t, []string{"/image"}, report.Versions[2].ChangedFields())
+	assert.Equal(t, "alice", report.Versions[1].Events[0].CreatedBy)
+	assert.Equal(t, "PUBLISHED", report.Versions[1].Events[1].Action.Code)
+
+	var b bytes.Buffer
+	assert.NoError(t, report.WriteMarkdown(&b))
+	assert.Equal(t, "# History of Banner\n"+
+		"\n## Version 3\n\n"+
+		"- 2026-01-03T10:00:00Z by bob: UPDATED\n"+
+		"\nChanged fields:\n\n"+
+		"- `/image` added\n"+
+		"\n## Version 2\n\n"+
+		"- 2026-01-02T10:00:00Z by alice: UPDATED\n"+
+		"- 2026-01-02T11:00:00Z by bob: PUBLISHED\n"+
+		"\nChanged fields:\n\n"+
+		"- `/title` changed\n"+
+		"\n## Version 1\n\n"+
+		"- 2026-01-01T10:00:00Z by alice: CREATED\n", b.String())
+}
@@ -0,0 +1,276 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// FacetField is a field of content items which can be used for facets and
+// filters in a ContentItemSearch.
+type FacetField string
+
+const (
+	FacetSchema           FacetField = "schema"
+	FacetLocale           FacetField = "locale"
+	FacetAssignees        FacetField = "assignees"
+	FacetWorkflowState    FacetField = "workflow.state"
+	FacetLastModifiedDate FacetField = "lastModifiedDate"
+	FacetCreatedDate      FacetField = "createdDate"
+)
+
+// DateRange is a range for date facets. Start and End are either a timestamp
+// or relative to now, such as `-7:DAYS` and `NOW`.
+type DateRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// DateRangeLastDays returns the range of the last days up to now
+func DateRangeLastDays(days int) DateRange {
+	return DateRange{Start: fmt.Sprintf("-%d:DAYS", days), End: "NOW"}
+}
+
+// DateRangeBetween returns the range between two times
+func DateRangeBetween(start time.Time, end time.Time) DateRange {
+	return DateRange{Start: start.UTC().Format(time.RFC3339), End: end.UTC().Format(time.RFC3339)}
+}
+
+type facetFilter struct {
+	Type   string   `json:"type"`
+	Values []string `json:"values"`
+}
+
+type facetFieldInput struct {
+	FacetAs string       `json:"facetAs"`
+	Field   FacetField   `json:"field"`
+	Range   *DateRange   `json:"range,omitempty"`
+	Filter  *facetFilter `json:"filter,omitempty"`
+}
+
+// ContentItemSearch searches the content items of a hub with facets. Filters
+// on the same field match any of the values, filters on different fields must
+// all match. Build a search with NewContentItemSearch and run it with
+// Client.ContentItemSearch.
+type ContentItemSearch struct {
+	text   string
+	fields []facetFieldInput
+	page   int
+	size   int
+	sort   string
+}
+
+// NewContentItemSearch creates a search for all content items
+func NewContentItemSearch() *ContentItemSearch {
+	return &ContentItemSearch{}
+}
+
+// Text searches for the given free text
+func (s *ContentItemSearch) Text(text string) *ContentItemSearch {
+	s.text = text
+	return s
+}
+
+// Facet adds the counts per value of the field to the results, without
+// filtering on it.
+func (s *ContentItemSearch) Facet(field FacetField) *ContentItemSearch {
+	s.field(field, "ENUM")
+	return s
+}
+
+// Filter only matches content items where the field has one of the values.
+// Values of earlier filters on the same field are kept. The field is also
+// added as facet.
+func (s *ContentItemSearch) Filter(field FacetField, values ...string) *ContentItemSearch {
+	input := s.field(field, "ENUM")
+	if input.Filter == nil || input.Filter.Type != "IN" {
+		input.Filter = &facetFilter{Type: "IN"}
+	}
+	input.Filter.Values = append(input.Filter.Values, values...)
+	return s
+}
+
+// DateRange only matches content items where the date field is within the
+// range, replacing earlier filters on the field. The range is also added as
+// facet.
+func (s *ContentItemSearch) DateRange(field FacetField, r DateRange) *ContentItemSearch {
+	input := s.field(field, "DATE")
+	input.FacetAs = "DATE"
+	input.Range = &r
+	input.Filter = &facetFilter{Type: "DATE", Values: []string{r.Start + "," + r.End}}
+	return s
+}
+
+// Page sets the page of the results to return, and the number of items per
+// page.
+func (s *ContentItemSearch) Page(page int, size int) *ContentItemSearch {
+	s.page = page
+	s.size = size
+	return s
+}
+
+// Sort sets the sort order of the results, for example `createdDate,desc`
+func (s *ContentItemSearch) Sort(sort string) *ContentItemSearch {
+	s.sort = sort
+	return s
+}
+
+// field returns the input of the field, which is added when needed
+func (s *ContentItemSearch) field(field FacetField, facetAs string) *facetFieldInput {
+	for i := range s.fields {
+		if s.fields[i].Field == field {
+			return &s.fields[i]
+		}
+	}
+	s.fields = append(s.fields, facetFieldInput{FacetAs: facetAs, Field: field})
+	return &s.fields[len(s.fields)-1]
+}
+
+// clone returns a copy of the search which is not changed by later calls on s
+func (s *ContentItemSearch) clone() ContentItemSearch {
+	result := *s
+	result.fields = make([]facetFieldInput, len(s.fields))
+	for i, input := range s.fields {
+		if input.Filter != nil {
+			filter := *input.Filter
+			filter.Values = append([]string(nil), filter.Values...)
+			input.Filter = &filter
+		}
+		result.fields[i] = input
+	}
+	return result
+}
+
+func (s *ContentItemSearch) queryString() string {
+	q := url.Values{}
+	if s.page > 0 {
+		q.Add("page", strconv.Itoa(s.page))
+	}
+	if s.size > 0 {
+		q.Add("size", strconv.Itoa(s.size))
+	}
+	if s.sort != "" {
+		q.Add("sort", s.sort)
+	}
+	if s.text != "" {
+		q.Add("query", s.text)
+	}
+	return q.Encode()
+}
+
+// MarshalJSON encodes the body of the search request
+func (s *ContentItemSearch) MarshalJSON() ([]byte, error) {
+	fields := s.fields
+	if fields == nil {
+		fields = []facetFieldInput{}
+	}
+	return json.Marshal(struct {
+		Fields         []facetFieldInput `json:"fields"`
+		ReturnEntities bool              `json:"returnEntities"`
+	}{fields, true})
+}
+
+// FacetCount is the number of matching content items with a value
+type FacetCount struct {
+	Value string `json:"_id"`
+	Count int    `json:"count"`
+}
+
+// ContentItemSearchResults is returned by the ContentItemSearch func
+type ContentItemSearchResults struct {
+	ContentItemResults
+	Facets map[FacetField][]FacetCount
+
+	hubID  string
+	search ContentItemSearch
+}
+
+// UnmarshalJSON is a custom unmarshaller for the embedded content and facets
+func (r *ContentItemSearchResults) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &r.ContentItemResults); err != nil {
+		return err
+	}
+
+	facets := struct {
+		Facets map[FacetField][]FacetCount `json:"_facets"`
+	}{}
+	if err := json.Unmarshal(data, &facets); err != nil {
+		return err
+	}
+	r.Facets = facets.Facets
+	return nil
+}
+
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ContentItemSearchResults) Next(client *Client) (ContentItemSearchResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ContentItemSearchResults) NextWithContext(ctx context.Context, client *Client) (ContentItemSearchResults, error) {
+	if r.Page.Number+1 >= r.Page.TotalPages {
+		return ContentItemSearchResults{}, ErrLinkNotFound
+	}
+	search := r.search
+	search.page = r.Page.Number + 1
+	return client.ContentItemSearchWithContext(ctx, r.hubID, &search)
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ContentItemSearchResults) Prev(client *Client) (ContentItemSearchResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ContentItemSearchResults) PrevWithContext(ctx context.Context, client *Client) (ContentItemSearchResults, error) {
+	if r.Page.Number <= 0 {
+		return ContentItemSearchResults{}, ErrLinkNotFound
+	}
+	search := r.search
+	search.page = r.Page.Number - 1
+	return client.ContentItemSearchWithContext(ctx, r.hubID, &search)
+}
+
+// ContentItemSearch searches the content items of a hub
+func (client *Client) ContentItemSearch(hubID string, search *ContentItemSearch) (ContentItemSearchResults, error) {
+	return client.ContentItemSearchWithContext(context.Background(), hubID, search)
+}
+
+// ContentItemSearchWithContext is the same as ContentItemSearch with a custom context
+func (client *Client) ContentItemSearchWithContext(ctx context.Context, hubID string, search *ContentItemSearch) (ContentItemSearchResults, error) {
+	result := ContentItemSearchResults{}
+	body, err := json.Marshal(search)
+	if err != nil {
+		return result, err
+	}
+
+	endpoint := fmt.Sprintf("/hubs/%s/content-items/facet?%s", hubID, search.queryString())
+	err = client.readRequest(ctx, "ContentItemSearch", http.MethodPost, endpoint, body, &result)
+	result.hubID = hubID
+	result.search = search.clone()
+	return result, err
+}
+
+// ContentItemSearchIterate returns an iterator over all content items matching
+// the search. Pages are fetched as needed, so iteration can be stopped early.
+// The page of the search is ignored.
+func (client *Client) ContentItemSearchIterate(hubID string, search *ContentItemSearch) iter.Seq2[ContentItem, error] {
+	return client.ContentItemSearchIterateWithContext(context.Background(), hubID, search)
+}
+
+// ContentItemSearchIterateWithContext is the same as ContentItemSearchIterate with a custom context
+func (client *Client) ContentItemSearchIterateWithContext(ctx context.Context, hubID string, search *ContentItemSearch) iter.Seq2[ContentItem, error] {
+	return Iterate(func(page int) ([]ContentItem, PageInformation, error) {
+		current := *search
+		current.page = page
+		response, err := client.ContentItemSearchWithContext(ctx, hubID, &current)
+		return response.Items, response.Page, err
+	})
+}
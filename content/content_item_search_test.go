@@ -0,0 +1,101 @@
+package content
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentItemSearch(t *testing.T) {
+	var bodies []string
+	var queries []string
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/hubs/hub/content-items/facet", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		queries = append(queries, r.URL.RawQuery)
+
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "0"
+		}
+		fmt.Fprintf(w, `{
+			"_embedded": {"content-items": [{"id": "item-%s"}]},
+			"_facets": {"schema": [{"_id": "https://example.com/banner.json", "count": 2}]},
+			"page": {"number": %s, "size": 1, "totalPages": 2, "totalElements": 2}
+		}`, page, page)
+	})
+
+	search := NewContentItemSearch().
+		Text("summer").
+		Filter(FacetSchema, "https://example.com/banner.json").
+		Filter(FacetWorkflowState, "a").
+		Filter(FacetWorkflowState, "b").
+		Facet(FacetLastModifiedDate).
+		DateRange(FacetLastModifiedDate, DateRangeLastDays(7)).
+		Facet(FacetLocale).
+		Page(0, 1)
+
+	results, err := client.ContentItemSearch("hub", search)
+	assert.NoError(t, err)
+	assert.Equal(t, "item-0", results.Items[0].ID)
+	assert.Equal(t, []FacetCount{{Value: "https://example.com/banner.json", Count: 2}}, results.Facets[FacetSchema])
+	assert.Equal(t, "query=summer&size=1", queries[0])
+	assert.JSONEq(t, `{
+		"fields": [
+			{"facetAs": "ENUM", "field": "schema", "filter": {"type": "IN", "values": ["https://example.com/banner.json"]}},
+			{"facetAs": "ENUM", "field": "workflow.state", "filter": {"type": "IN", "values": ["a", "b"]}},
+			{"facetAs": "DATE", "field": "lastModifiedDate", "range": {"start": "-7:DAYS", "end": "NOW"},
+				"filter": {"type": "DATE", "values": ["-7:DAYS,NOW"]}},
+			{"facetAs": "ENUM", "field": "locale"}
+		],
+		"returnEntities": true
+	}`, bodies[0])
+
+	next, err := results.Next(client)
+	assert.NoError(t, err)
+	assert.Equal(t, "item-1", next.Items[0].ID)
+	assert.Equal(t, bodies[0], bodies[1])
+	_, err = next.Next(client)
+	assert.ErrorIs(t, err, ErrLinkNotFound)
+
+	items, err := Collect(client.ContentItemSearchIterate("hub", search))
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+}
+
+func TestContentItemSearchReadOnly(t *testing.T) {
+	var calls int32
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{"_embedded": {"content-items": [{"id": "item"}]}, "page": {"number": 0, "totalPages": 1}}`))
+	}
+	search := NewContentItemSearch().Filter(FacetSchema, "https://example.com/banner.json")
+
+	configs := map[string]ClientConfig{
+		"dry run":   {DryRun: true, RetryPolicy: testRetryPolicy()},
+		"read-only": {Middlewares: []Middleware{ReadOnly}, RetryPolicy: testRetryPolicy()},
+	}
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			atomic.StoreInt32(&calls, 0)
+			client := newTestClient(t, config, handler)
+
+			items, err := Collect(client.ContentItemSearchIterate("hub", search))
+			assert.NoError(t, err)
+			assert.Len(t, items, 1)
+			assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "the search is retried after a 502")
+			if plan := client.Plan(); plan != nil {
+				assert.Empty(t, plan.Requests())
+			}
+		})
+	}
+}
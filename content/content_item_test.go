@@ -0,0 +1,193 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentItemUpdateWithRetry(t *testing.T) {
+	var version int32 = 1
+	var patches int32
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			v := atomic.LoadInt32(&version)
+			json.NewEncoder(w).Encode(ContentItem{ID: "item-id", Version: int(v), Body: map[string]interface{}{"title": "old"}})
+		case http.MethodPatch:
+			atomic.AddInt32(&patches, 1)
+			body, _ := ioutil.ReadAll(r.Body)
+			patch := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(body, &patch))
+			assert.Equal(t, map[string]interface{}{"title": "new"}, patch["body"])
+
+			// Someone else updates the item right before our first update
+			if atomic.AddInt32(&version, 1) == 2 {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"errors": [{"message": "version conflict"}]}`))
+				return
+			}
+			assert.Equal(t, float64(2), patch["version"])
+			json.NewEncoder(w).Encode(ContentItem{ID: "item-id", Version: 4, Body: map[string]interface{}{"title": "new"}})
+		}
+	})
+
+	item, err := client.ContentItemUpdateWithRetry("item-id", 3, func(input *ContentItemInput) error {
+		input.Body["title"] = "new"
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, item.Version)
+	assert.Equal(t, int32(2), patches)
+}
+
+func TestContentItemUpdateWithRetryEmptyBody(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id": "item-id", "version": 1, "body": null}`))
+		case http.MethodPatch:
+			body, _ := ioutil.ReadAll(r.Body)
+			patch := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(body, &patch))
+			assert.Equal(t, map[string]interface{}{"title": "new"}, patch["body"])
+			w.Write([]byte(`{"id": "item-id", "version": 2, "body": {"title": "new"}}`))
+		}
+	})
+
+	item, err := client.ContentItemUpdateWithRetry("item-id", 1, func(input *ContentItemInput) error {
+		input.Body["title"] = "new"
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, item.Version)
+}
+
+func TestContentItemRestoreVersion(t *testing.T) {
+	var patch map[string]interface{}
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/content-items/item-id/versions/1":
+			w.Write([]byte(`{"id": "item-id", "version": 1, "label": "Old", "body": {"title": "old", "tags": ["a"]}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/content-items/item-id/versions/2":
+			w.Write([]byte(`{"id": "item-id", "version": 2, "label": "New", "body": {"title": "new", "tags": ["a", "b"], "extra": true}}`))
+		case r.Method == http.MethodPatch:
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &patch))
+			w.Write([]byte(`{"id": "item-id", "version": 3}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	ops, err := client.ContentItemDiffVersions("item-id", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []PatchOperation{
+		{Op: "add", Path: "/extra", Value: true},
+		{Op: "add", Path: "/tags/1", Value: "b"},
+		{Op: "replace", Path: "/title", Value: "new"},
+	}, ops)
+
+	current := ContentItem{ID: "item-id", Version: 2, Label: "New", Body: map[string]interface{}{
+		"title": "new", "tags": []interface{}{"a", "b"}, "extra": true,
+	}}
+	result, err := client.ContentItemRestoreVersion(current, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Version)
+	assert.Equal(t, map[string]interface{}{
+		"version": float64(2),
+		"label":   "Old",
+		"body":    map[string]interface{}{"title": "old", "tags": []interface{}{"a"}, "extra": nil},
+	}, patch)
+}
+
+func TestContentItemArchive(t *testing.T) {
+	var requests []string
+	var versions []float64
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"id": "item-id", "version": 5}`))
+		case r.URL.Path == "/content-items/item-id/archive":
+			input := map[string]interface{}{}
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &input))
+			versions = append(versions, input["version"].(float64))
+			if len(versions) == 1 {
+				w.WriteHeader(http.StatusConflict)
+				w.Write([]byte(`{"errors": [{"message": "version mismatch"}]}`))
+				return
+			}
+			w.Write([]byte(`{"id": "item-id", "version": 6, "status": "ARCHIVED"}`))
+		case r.URL.Path == "/content-items/item-id/unarchive":
+			w.Write([]byte(`{"id": "item-id", "version": 7, "status": "ACTIVE"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	item, err := client.ContentItemArchive("item-id", 4, ArchiveRetryOnConflict(1))
+	assert.NoError(t, err)
+	assert.Equal(t, "ARCHIVED", item.Status)
+	assert.Equal(t, []float64{4, 5}, versions)
+
+	item, err = client.ContentItemUnarchive("item-id", 6)
+	assert.NoError(t, err)
+	assert.Equal(t, "ACTIVE", item.Status)
+	assert.Equal(t, []string{
+		"POST /content-items/item-id/archive",
+		"GET /content-items/item-id",
+		"POST /content-items/item-id/archive",
+		"POST /content-items/item-id/unarchive",
+	}, requests)
+
+	// Without the option a conflict is returned as is
+	versions = nil
+	_, err = client.ContentItemArchive("item-id", 4)
+	assert.True(t, IsConflict(err))
+}
+
+func TestContentItemArchiveRecursive(t *testing.T) {
+	var archived []string
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/content-items/root/hierarchy/children":
+			w.Write([]byte(`{"_embedded": {"content-items": [
+				{"id": "child", "version": 1, "status": "ACTIVE"},
+				{"id": "old", "version": 1, "status": "ARCHIVED"}
+			]}, "page": {"number": 0, "totalPages": 1}}`))
+		case "/content-items/child/hierarchy/children":
+			w.Write([]byte(`{"_embedded": {"content-items": [{"id": "grandchild", "version": 2, "status": "ACTIVE"}]}, "page": {"number": 0, "totalPages": 1}}`))
+		case "/content-items/grandchild/hierarchy/children":
+			w.Write([]byte(`{"_embedded": {"content-items": []}, "page": {"number": 0, "totalPages": 1}}`))
+		case "/content-items/root/archive", "/content-items/child/archive", "/content-items/grandchild/archive":
+			id := strings.Split(r.URL.Path, "/")[2]
+			archived = append(archived, id)
+			fmt.Fprintf(w, `{"id": %q, "status": "ARCHIVED"}`, id)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	item, err := client.ContentItemArchive("root", 1, ArchiveRecursive())
+	assert.NoError(t, err)
+	assert.Equal(t, "root", item.ID)
+	assert.Equal(t, []string{"grandchild", "child", "root"}, archived)
+}
+
+func TestContentItemDelete(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/content-items/item-id", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	assert.NoError(t, client.ContentItemDelete("item-id"))
+}
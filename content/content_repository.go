@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -22,9 +24,40 @@ type ContentRepository struct {
 	ContentTypes []ContentTypeReference `json:"contentTypes"`
 }
 
+// GetHub returns the hub of the Content Repository
 func (r *ContentRepository) GetHub(client *Client) (Hub, error) {
+	return r.GetHubWithContext(context.Background(), client)
+}
+
+// GetHubWithContext is the same as GetHub with a custom context
+func (r *ContentRepository) GetHubWithContext(ctx context.Context, client *Client) (Hub, error) {
 	result := Hub{}
-	err := client.request(http.MethodGet, r.Links["hub"].Href, nil, &result)
+	err := client.followRelation(ctx, "ContentRepository.GetHub", r.Links, "hub", &result)
+	return result, err
+}
+
+// GetContentItems returns the first page of Content Items in the Content
+// Repository
+func (r *ContentRepository) GetContentItems(client *Client) (ContentItemResults, error) {
+	return r.GetContentItemsWithContext(context.Background(), client)
+}
+
+// GetContentItemsWithContext is the same as GetContentItems with a custom context
+func (r *ContentRepository) GetContentItemsWithContext(ctx context.Context, client *Client) (ContentItemResults, error) {
+	result := ContentItemResults{}
+	err := client.followRelation(ctx, "ContentRepository.GetContentItems", r.Links, "content-items", &result)
+	return result, err
+}
+
+// GetFolders returns the first page of folders in the Content Repository
+func (r *ContentRepository) GetFolders(client *Client) (FolderResults, error) {
+	return r.GetFoldersWithContext(context.Background(), client)
+}
+
+// GetFoldersWithContext is the same as GetFolders with a custom context
+func (r *ContentRepository) GetFoldersWithContext(ctx context.Context, client *Client) (FolderResults, error) {
+	result := FolderResults{}
+	err := client.followRelation(ctx, "ContentRepository.GetFolders", r.Links, "folders", &result)
 	return result, err
 }
 
@@ -54,27 +87,66 @@ func (r *ContentRepositoryResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ContentRepositoryResults) Next(client *Client) (ContentRepositoryResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ContentRepositoryResults) NextWithContext(ctx context.Context, client *Client) (ContentRepositoryResults, error) {
+	result := ContentRepositoryResults{}
+	err := client.followRelation(ctx, "ContentRepositoryResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ContentRepositoryResults) Prev(client *Client) (ContentRepositoryResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ContentRepositoryResults) PrevWithContext(ctx context.Context, client *Client) (ContentRepositoryResults, error) {
+	result := ContentRepositoryResults{}
+	err := client.followRelation(ctx, "ContentRepositoryResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 // ContentRepositoryGet returns a ContentRepository for the given id
 func (client *Client) ContentRepositoryGet(id string) (ContentRepository, error) {
+	return client.ContentRepositoryGetWithContext(context.Background(), id)
+}
+
+// ContentRepositoryGetWithContext is the same as ContentRepositoryGet with a custom context
+func (client *Client) ContentRepositoryGetWithContext(ctx context.Context, id string) (ContentRepository, error) {
 	result := ContentRepository{}
 	endpoint := fmt.Sprintf("/content-repositories/%s", id)
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentRepositoryGet", http.MethodGet, endpoint, nil, &result)
 
 	return result, err
 }
 
 func (client *Client) ContentRepositoryCreate(hubID string, input ContentRepositoryInput) (ContentRepository, error) {
+	return client.ContentRepositoryCreateWithContext(context.Background(), hubID, input)
+}
+
+func (client *Client) ContentRepositoryCreateWithContext(ctx context.Context, hubID string, input ContentRepositoryInput) (ContentRepository, error) {
 	result := ContentRepository{}
 	body, err := json.Marshal(input)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/hubs/%s/content-repositories", hubID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentRepositoryCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentRepositoryUpdate(current ContentRepository, input ContentRepositoryInput) (ContentRepository, error) {
+	return client.ContentRepositoryUpdateWithContext(context.Background(), current, input)
+}
+
+func (client *Client) ContentRepositoryUpdateWithContext(ctx context.Context, current ContentRepository, input ContentRepositoryInput) (ContentRepository, error) {
 	result := ContentRepository{}
 
 	body, err := createUpdatePatch(
@@ -93,35 +165,44 @@ func (client *Client) ContentRepositoryUpdate(current ContentRepository, input C
 	}
 
 	endpoint := fmt.Sprintf("/content-repositories/%s", current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "ContentRepositoryUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentRepositoryList(hubID string, parameters PaginationParameters) (ContentRepositoryResults, error) {
+	return client.ContentRepositoryListWithContext(context.Background(), hubID, parameters)
+}
+
+func (client *Client) ContentRepositoryListWithContext(ctx context.Context, hubID string, parameters PaginationParameters) (ContentRepositoryResults, error) {
 	result := ContentRepositoryResults{}
 	endpoint := fmt.Sprintf("/hubs/%s/content-repositories?%s", hubID, PaginationQueryString(parameters))
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentRepositoryList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentRepositoryGetAll(hubID string) ([]ContentRepository, error) {
-	parameters := PaginationParameters{}
-
-	response, err := client.ContentRepositoryList(hubID, parameters)
+	return client.ContentRepositoryGetAllWithContext(context.Background(), hubID)
+}
 
-	var result []ContentRepository
-	result = append(result, response.Items...)
+func (client *Client) ContentRepositoryGetAllWithContext(ctx context.Context, hubID string) ([]ContentRepository, error) {
+	parameters := PaginationParameters{}
+	return Collect(client.ContentRepositoryIterateWithContext(ctx, hubID, parameters))
+}
 
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.ContentRepositoryList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+// ContentRepositoryIterate returns an iterator over all Content Repositories
+// within the given hub. Pages are fetched as needed, so iteration can be
+// stopped early. The Page parameter is ignored.
+func (client *Client) ContentRepositoryIterate(hubID string, parameters PaginationParameters) iter.Seq2[ContentRepository, error] {
+	return client.ContentRepositoryIterateWithContext(context.Background(), hubID, parameters)
+}
 
-	return result, err
+// ContentRepositoryIterateWithContext is the same as ContentRepositoryIterate with a custom context
+func (client *Client) ContentRepositoryIterateWithContext(ctx context.Context, hubID string, parameters PaginationParameters) iter.Seq2[ContentRepository, error] {
+	return Iterate(func(page int) ([]ContentRepository, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.ContentRepositoryListWithContext(ctx, hubID, parameters)
+		return response.Items, response.Page, err
+	})
 }
 
 func (client *Client) ContentRepositoryFind() {
@@ -134,6 +215,11 @@ func (client *Client) ContentRepositoryShare() {
 
 // ContentRepositoryAssignContentType assigns a Content Type to a Content Repository
 func (client *Client) ContentRepositoryAssignContentType(repositoryID string, typeID string) (ContentRepository, error) {
+	return client.ContentRepositoryAssignContentTypeWithContext(context.Background(), repositoryID, typeID)
+}
+
+// ContentRepositoryAssignContentTypeWithContext is the same as ContentRepositoryAssignContentType with a custom context
+func (client *Client) ContentRepositoryAssignContentTypeWithContext(ctx context.Context, repositoryID string, typeID string) (ContentRepository, error) {
 	result := ContentRepository{}
 	body, err := json.Marshal(struct {
 		TypeID string `json:"contentTypeId"`
@@ -144,15 +230,20 @@ func (client *Client) ContentRepositoryAssignContentType(repositoryID string, ty
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/content-repositories/%s/content-types", repositoryID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentRepositoryAssignContentType", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 // ContentRepositoryRemoveContentType removes a Content Type from a Content Repository
 func (client *Client) ContentRepositoryRemoveContentType(repositoryID string, typeID string) (ContentRepository, error) {
+	return client.ContentRepositoryRemoveContentTypeWithContext(context.Background(), repositoryID, typeID)
+}
+
+// ContentRepositoryRemoveContentTypeWithContext is the same as ContentRepositoryRemoveContentType with a custom context
+func (client *Client) ContentRepositoryRemoveContentTypeWithContext(ctx context.Context, repositoryID string, typeID string) (ContentRepository, error) {
 	result := ContentRepository{}
 	endpoint := fmt.Sprintf("/content-repositories/%s/content-types/%s", repositoryID, typeID)
-	err := client.request(http.MethodDelete, endpoint, nil, &result)
+	err := client.request(ctx, "ContentRepositoryRemoveContentType", http.MethodDelete, endpoint, nil, &result)
 	return result, err
 }
 
@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -61,28 +63,66 @@ func (r *ContentTypeResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ContentTypeResults) Next(client *Client) (ContentTypeResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ContentTypeResults) NextWithContext(ctx context.Context, client *Client) (ContentTypeResults, error) {
+	result := ContentTypeResults{}
+	err := client.followRelation(ctx, "ContentTypeResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ContentTypeResults) Prev(client *Client) (ContentTypeResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ContentTypeResults) PrevWithContext(ctx context.Context, client *Client) (ContentTypeResults, error) {
+	result := ContentTypeResults{}
+	err := client.followRelation(ctx, "ContentTypeResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 func (client *Client) ContentTypeCreate(hubID string, input ContentTypeInput) (ContentType, error) {
+	return client.ContentTypeCreateWithContext(context.Background(), hubID, input)
+}
+
+func (client *Client) ContentTypeCreateWithContext(ctx context.Context, hubID string, input ContentTypeInput) (ContentType, error) {
 	result := ContentType{}
 	body, err := json.Marshal(input)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/hubs/%s/content-types", hubID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeGet(id string) (ContentType, error) {
+	return client.ContentTypeGetWithContext(context.Background(), id)
+}
+
+func (client *Client) ContentTypeGetWithContext(ctx context.Context, id string) (ContentType, error) {
 	endpoint := fmt.Sprintf("/content-types/%s", id)
 	result := ContentType{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentTypeGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeFindByUri(uri string, hubId string) (ContentType, error) {
+	return client.ContentTypeFindByUriWithContext(context.Background(), uri, hubId)
+}
+
+func (client *Client) ContentTypeFindByUriWithContext(ctx context.Context, uri string, hubId string) (ContentType, error) {
 	dummy := ContentType{}
-	allItems, getErr := client.ContentTypeGetAll(hubId, StatusAny)
+	allItems, getErr := client.ContentTypeGetAllWithContext(ctx, hubId, StatusAny)
 
 	if getErr != nil {
 		return dummy, getErr
@@ -98,6 +138,10 @@ func (client *Client) ContentTypeFindByUri(uri string, hubId string) (ContentTyp
 }
 
 func (client *Client) ContentTypeUpdate(current ContentType, input ContentTypeInput) (ContentType, error) {
+	return client.ContentTypeUpdateWithContext(context.Background(), current, input)
+}
+
+func (client *Client) ContentTypeUpdateWithContext(ctx context.Context, current ContentType, input ContentTypeInput) (ContentType, error) {
 	result := ContentType{}
 
 	body, err := createUpdatePatch(
@@ -115,19 +159,27 @@ func (client *Client) ContentTypeUpdate(current ContentType, input ContentTypeIn
 	}
 
 	endpoint := fmt.Sprintf("/content-types/%s", current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeList(hubID string, parameters StatusPaginationParameters) (ContentTypeResults, error) {
+	return client.ContentTypeListWithContext(context.Background(), hubID, parameters)
+}
+
+func (client *Client) ContentTypeListWithContext(ctx context.Context, hubID string, parameters StatusPaginationParameters) (ContentTypeResults, error) {
 	result := ContentTypeResults{}
 	endpoint := fmt.Sprintf("/hubs/%s/content-types?%s", hubID, ContentTypePaginationQueryString(parameters))
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentTypeList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeSyncSchema(current ContentType) (ContentTypeSyncResult, error) {
+	return client.ContentTypeSyncSchemaWithContext(context.Background(), current)
+}
+
+func (client *Client) ContentTypeSyncSchemaWithContext(ctx context.Context, current ContentType) (ContentTypeSyncResult, error) {
 	result := ContentTypeSyncResult{}
 
 	var emptyInput struct{}
@@ -139,43 +191,56 @@ func (client *Client) ContentTypeSyncSchema(current ContentType) (ContentTypeSyn
 
 	endpoint := fmt.Sprintf("/content-types/%s/schema", current.ID)
 
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeSyncSchema", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeGetAll(hubID string, status ContentStatus) ([]ContentType, error) {
+	return client.ContentTypeGetAllWithContext(context.Background(), hubID, status)
+}
+
+func (client *Client) ContentTypeGetAllWithContext(ctx context.Context, hubID string, status ContentStatus) ([]ContentType, error) {
 	parameters := StatusPaginationParameters{}
 	parameters.Status = status
+	return Collect(client.ContentTypeIterateWithContext(ctx, hubID, parameters))
+}
 
-	response, err := client.ContentTypeList(hubID, parameters)
-
-	var result []ContentType
-	result = append(result, response.Items...)
-
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.ContentTypeList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+// ContentTypeIterate returns an iterator over all Content Types within the
+// given hub. Pages are fetched as needed, so iteration can be stopped early.
+// The Page parameter is ignored.
+func (client *Client) ContentTypeIterate(hubID string, parameters StatusPaginationParameters) iter.Seq2[ContentType, error] {
+	return client.ContentTypeIterateWithContext(context.Background(), hubID, parameters)
+}
 
-	return result, err
+// ContentTypeIterateWithContext is the same as ContentTypeIterate with a custom context
+func (client *Client) ContentTypeIterateWithContext(ctx context.Context, hubID string, parameters StatusPaginationParameters) iter.Seq2[ContentType, error] {
+	return Iterate(func(page int) ([]ContentType, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.ContentTypeListWithContext(ctx, hubID, parameters)
+		return response.Items, response.Page, err
+	})
 }
 
 func (client *Client) ContentTypeArchive(id string) (ContentType, error) {
+	return client.ContentTypeArchiveWithContext(context.Background(), id)
+}
+
+func (client *Client) ContentTypeArchiveWithContext(ctx context.Context, id string) (ContentType, error) {
 	result := ContentType{}
 	endpoint := fmt.Sprintf("/content-types/%s/archive", id)
 
-	err := client.request(http.MethodPost, endpoint, nil, &result)
+	err := client.request(ctx, "ContentTypeArchive", http.MethodPost, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeUnarchive(id string) (ContentType, error) {
+	return client.ContentTypeUnarchiveWithContext(context.Background(), id)
+}
+
+func (client *Client) ContentTypeUnarchiveWithContext(ctx context.Context, id string) (ContentType, error) {
 	result := ContentType{}
 	endpoint := fmt.Sprintf("/content-types/%s/unarchive", id)
 
-	err := client.request(http.MethodPost, endpoint, nil, &result)
+	err := client.request(ctx, "ContentTypeUnarchive", http.MethodPost, endpoint, nil, &result)
 	return result, err
 }
@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -21,6 +23,15 @@ type ContentTypeSchema struct {
 	ValidationLevel  string          `json:"validationLevel"`
 }
 
+// Validation levels of content type schemas. Content types and slots can be
+// used for content items, partials only contain definitions used by other
+// schemas.
+const (
+	ValidationLevelContentType = "CONTENT_TYPE"
+	ValidationLevelSlot        = "SLOT"
+	ValidationLevelPartial     = "PARTIAL"
+)
+
 type ContentTypeSchemaInput struct {
 	SchemaID        string `json:"schemaId,omitempty"`
 	Body            string `json:"body,omitempty"`
@@ -48,28 +59,79 @@ func (r *ContentTypeSchemaResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ContentTypeSchemaResults) Next(client *Client) (ContentTypeSchemaResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ContentTypeSchemaResults) NextWithContext(ctx context.Context, client *Client) (ContentTypeSchemaResults, error) {
+	result := ContentTypeSchemaResults{}
+	err := client.followRelation(ctx, "ContentTypeSchemaResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ContentTypeSchemaResults) Prev(client *Client) (ContentTypeSchemaResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ContentTypeSchemaResults) PrevWithContext(ctx context.Context, client *Client) (ContentTypeSchemaResults, error) {
+	result := ContentTypeSchemaResults{}
+	err := client.followRelation(ctx, "ContentTypeSchemaResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
+// GetContentTypes returns the first page of Content Types which use the
+// schema
+func (r *ContentTypeSchema) GetContentTypes(client *Client) (ContentTypeResults, error) {
+	return r.GetContentTypesWithContext(context.Background(), client)
+}
+
+// GetContentTypesWithContext is the same as GetContentTypes with a custom context
+func (r *ContentTypeSchema) GetContentTypesWithContext(ctx context.Context, client *Client) (ContentTypeResults, error) {
+	result := ContentTypeResults{}
+	err := client.followRelation(ctx, "ContentTypeSchema.GetContentTypes", r.Links, "content-types", &result)
+	return result, err
+}
+
 func (client *Client) ContentTypeSchemaCreate(hubID string, update ContentTypeSchemaInput) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaCreateWithContext(context.Background(), hubID, update)
+}
+
+func (client *Client) ContentTypeSchemaCreateWithContext(ctx context.Context, hubID string, update ContentTypeSchemaInput) (ContentTypeSchema, error) {
 	result := ContentTypeSchema{}
 	body, err := json.Marshal(update)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/hubs/%s/content-type-schemas", hubID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeSchemaCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeSchemaGet(id string) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaGetWithContext(context.Background(), id)
+}
+
+func (client *Client) ContentTypeSchemaGetWithContext(ctx context.Context, id string) (ContentTypeSchema, error) {
 	endpoint := fmt.Sprintf("/content-type-schemas/%s", id)
 	result := ContentTypeSchema{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentTypeSchemaGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeSchemaFindBySchemaId(schemaId string, hubId string) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaFindBySchemaIdWithContext(context.Background(), schemaId, hubId)
+}
+
+func (client *Client) ContentTypeSchemaFindBySchemaIdWithContext(ctx context.Context, schemaId string, hubId string) (ContentTypeSchema, error) {
 	dummy := ContentTypeSchema{}
-	allItems, getErr := client.ContentTypeSchemaGetAll(hubId, StatusAny)
+	allItems, getErr := client.ContentTypeSchemaGetAllWithContext(ctx, hubId, StatusAny)
 
 	if getErr != nil {
 		return dummy, getErr
@@ -85,6 +147,10 @@ func (client *Client) ContentTypeSchemaFindBySchemaId(schemaId string, hubId str
 }
 
 func (client *Client) ContentTypeSchemaUpdate(current ContentTypeSchema, update ContentTypeSchemaInput) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaUpdateWithContext(context.Background(), current, update)
+}
+
+func (client *Client) ContentTypeSchemaUpdateWithContext(ctx context.Context, current ContentTypeSchema, update ContentTypeSchemaInput) (ContentTypeSchema, error) {
 	result := ContentTypeSchema{}
 
 	body, err := createUpdatePatch(
@@ -104,41 +170,54 @@ func (client *Client) ContentTypeSchemaUpdate(current ContentTypeSchema, update
 	}
 
 	endpoint := fmt.Sprintf("/content-type-schemas/%s", current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeSchemaUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeSchemaList(hubID string, parameters StatusPaginationParameters) (ContentTypeSchemaResults, error) {
+	return client.ContentTypeSchemaListWithContext(context.Background(), hubID, parameters)
+}
+
+func (client *Client) ContentTypeSchemaListWithContext(ctx context.Context, hubID string, parameters StatusPaginationParameters) (ContentTypeSchemaResults, error) {
 	result := ContentTypeSchemaResults{}
 
 	endpoint := fmt.Sprintf("/hubs/%s/content-type-schemas?%s", hubID, ContentTypeSchemaPaginationQueryString(parameters))
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ContentTypeSchemaList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeSchemaGetAll(hubID string, status ContentStatus) ([]ContentTypeSchema, error) {
+	return client.ContentTypeSchemaGetAllWithContext(context.Background(), hubID, status)
+}
+
+func (client *Client) ContentTypeSchemaGetAllWithContext(ctx context.Context, hubID string, status ContentStatus) ([]ContentTypeSchema, error) {
 	parameters := StatusPaginationParameters{}
 	parameters.Status = status
+	return Collect(client.ContentTypeSchemaIterateWithContext(ctx, hubID, parameters))
+}
 
-	response, err := client.ContentTypeSchemaList(hubID, parameters)
-
-	var result []ContentTypeSchema
-	result = append(result, response.Items...)
-
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.ContentTypeSchemaList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+// ContentTypeSchemaIterate returns an iterator over all Content Type Schemas
+// within the given hub. Pages are fetched as needed, so iteration can be
+// stopped early. The Page parameter is ignored.
+func (client *Client) ContentTypeSchemaIterate(hubID string, parameters StatusPaginationParameters) iter.Seq2[ContentTypeSchema, error] {
+	return client.ContentTypeSchemaIterateWithContext(context.Background(), hubID, parameters)
+}
 
-	return result, err
+// ContentTypeSchemaIterateWithContext is the same as ContentTypeSchemaIterate with a custom context
+func (client *Client) ContentTypeSchemaIterateWithContext(ctx context.Context, hubID string, parameters StatusPaginationParameters) iter.Seq2[ContentTypeSchema, error] {
+	return Iterate(func(page int) ([]ContentTypeSchema, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.ContentTypeSchemaListWithContext(ctx, hubID, parameters)
+		return response.Items, response.Page, err
+	})
 }
 
 func (client *Client) ContentTypeSchemaArchive(id string, version int) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaArchiveWithContext(context.Background(), id, version)
+}
+
+func (client *Client) ContentTypeSchemaArchiveWithContext(ctx context.Context, id string, version int) (ContentTypeSchema, error) {
 	result := ContentTypeSchema{}
 	endpoint := fmt.Sprintf("/content-type-schemas/%s/archive", id)
 
@@ -147,11 +226,15 @@ func (client *Client) ContentTypeSchemaArchive(id string, version int) (ContentT
 		return result, err
 	}
 
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeSchemaArchive", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ContentTypeSchemaUnarchive(id string, version int) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaUnarchiveWithContext(context.Background(), id, version)
+}
+
+func (client *Client) ContentTypeSchemaUnarchiveWithContext(ctx context.Context, id string, version int) (ContentTypeSchema, error) {
 	result := ContentTypeSchema{}
 	endpoint := fmt.Sprintf("/content-type-schemas/%s/unarchive", id)
 
@@ -160,6 +243,131 @@ func (client *Client) ContentTypeSchemaUnarchive(id string, version int) (Conten
 		return result, err
 	}
 
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ContentTypeSchemaUnarchive", http.MethodPost, endpoint, body, &result)
+	return result, err
+}
+
+// ContentTypeSchemaGetVersion returns the content type schema as it was at the
+// given version.
+func (client *Client) ContentTypeSchemaGetVersion(id string, version int) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaGetVersionWithContext(context.Background(), id, version)
+}
+
+// ContentTypeSchemaGetVersionWithContext is the same as ContentTypeSchemaGetVersion with a custom context
+func (client *Client) ContentTypeSchemaGetVersionWithContext(ctx context.Context, id string, version int) (ContentTypeSchema, error) {
+	endpoint := fmt.Sprintf("/content-type-schemas/%s/%d", id, version)
+	result := ContentTypeSchema{}
+
+	err := client.request(ctx, "ContentTypeSchemaGetVersion", http.MethodGet, endpoint, nil, &result)
+	return result, err
+}
+
+// ContentTypeSchemaListVersions returns every version of the content type
+// schema, oldest first. Since the API has no list of versions, each version is
+// fetched separately with one request per version, so this can be slow for
+// schemas with many versions.
+func (client *Client) ContentTypeSchemaListVersions(id string) ([]ContentTypeSchema, error) {
+	return client.ContentTypeSchemaListVersionsWithContext(context.Background(), id)
+}
+
+// ContentTypeSchemaListVersionsWithContext is the same as ContentTypeSchemaListVersions with a custom context
+func (client *Client) ContentTypeSchemaListVersionsWithContext(ctx context.Context, id string) ([]ContentTypeSchema, error) {
+	current, err := client.ContentTypeSchemaGetWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]ContentTypeSchema, 0, current.Version)
+	for version := 1; version < current.Version; version++ {
+		item, err := client.ContentTypeSchemaGetVersionWithContext(ctx, id, version)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return append(result, current), nil
+}
+
+// ContentTypeSchemaDiffVersions returns the JSON Patch which changes the body
+// of the schema at version from into the body at version to.
+func (client *Client) ContentTypeSchemaDiffVersions(id string, from int, to int) ([]PatchOperation, error) {
+	return client.ContentTypeSchemaDiffVersionsWithContext(context.Background(), id, from, to)
+}
+
+// ContentTypeSchemaDiffVersionsWithContext is the same as ContentTypeSchemaDiffVersions with a custom context
+func (client *Client) ContentTypeSchemaDiffVersionsWithContext(ctx context.Context, id string, from int, to int) ([]PatchOperation, error) {
+	src, err := client.ContentTypeSchemaGetVersionWithContext(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := client.ContentTypeSchemaGetVersionWithContext(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+	return DiffContentTypeSchemas(src, dst)
+}
+
+// DiffContentTypeSchemas returns the JSON Patch which changes the body of
+// schema from into the body of schema to.
+func DiffContentTypeSchemas(from ContentTypeSchema, to ContentTypeSchema) ([]PatchOperation, error) {
+	var src, dst interface{}
+	if err := json.Unmarshal([]byte(from.Body), &src); err != nil {
+		return nil, fmt.Errorf("body of version %d: %w", from.Version, err)
+	}
+	if err := json.Unmarshal([]byte(to.Body), &dst); err != nil {
+		return nil, fmt.Errorf("body of version %d: %w", to.Version, err)
+	}
+	return DiffJSON(src, dst)
+}
+
+// ContentTypeSchemaRollback updates the content type schema to the body it had
+// at the given version. This creates a new version of the schema.
+//
+// Like ContentItemRestoreVersion the update is based on the version of the
+// schema when the rollback started, so it fails with an error for which
+// IsConflict returns true when the schema was modified in the meantime.
+func (client *Client) ContentTypeSchemaRollback(id string, version int) (ContentTypeSchema, error) {
+	return client.ContentTypeSchemaRollbackWithContext(context.Background(), id, version)
+}
+
+// ContentTypeSchemaRollbackWithContext is the same as ContentTypeSchemaRollback with a custom context
+func (client *Client) ContentTypeSchemaRollbackWithContext(ctx context.Context, id string, version int) (ContentTypeSchema, error) {
+	current, err := client.ContentTypeSchemaGetWithContext(ctx, id)
+	if err != nil {
+		return current, err
+	}
+	previous, err := client.ContentTypeSchemaGetVersionWithContext(ctx, id, version)
+	if err != nil {
+		return current, err
+	}
+
+	body, err := createUpdatePatch(
+		ContentTypeSchemaInput{
+			Body:            current.Body,
+			ValidationLevel: current.ValidationLevel,
+			SchemaID:        current.SchemaID,
+		},
+		ContentTypeSchemaInput{
+			Body:            previous.Body,
+			ValidationLevel: current.ValidationLevel,
+			SchemaID:        current.SchemaID,
+		})
+	if err != nil {
+		return current, err
+	}
+	if body == nil {
+		return current, nil
+	}
+
+	// Send the version the rollback is based on, so it is rejected when the
+	// schema was modified in the meantime.
+	body, err = addPatchVersion(body, current.Version)
+	if err != nil {
+		return current, err
+	}
+
+	result := ContentTypeSchema{}
+	endpoint := fmt.Sprintf("/content-type-schemas/%s", current.ID)
+	err = client.request(ctx, "ContentTypeSchemaRollback", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
@@ -0,0 +1,86 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentTypeSchemaVersions(t *testing.T) {
+	bodies := map[string]string{
+		"1": `{"properties": {"title": {"type": "string"}}}`,
+		"2": `{"properties": {"title": {"type": "string"}, "image": {"type": "object"}}}`,
+		"3": `{"properties": {"headline": {"type": "string"}}}`,
+	}
+	var requests []string
+	var patch map[string]interface{}
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/content-type-schemas/schema-id":
+			if r.Method == http.MethodPatch {
+				body, _ := ioutil.ReadAll(r.Body)
+				assert.NoError(t, json.Unmarshal(body, &patch))
+				fmt.Fprintf(w, `{"id": "schema-id", "version": 4, "body": %q}`, patch["body"])
+				return
+			}
+			fmt.Fprintf(w, `{"id": "schema-id", "version": 3, "schemaId": "https://example.com/banner.json", "validationLevel": "CONTENT_TYPE", "body": %q}`, bodies["3"])
+		case "/content-type-schemas/schema-id/1", "/content-type-schemas/schema-id/2":
+			version := r.URL.Path[len(r.URL.Path)-1:]
+			fmt.Fprintf(w, `{"id": "schema-id", "version": %s, "body": %q}`, version, bodies[version])
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	schema, err := client.ContentTypeSchemaGetVersion("schema-id", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, schema.Version)
+	assert.JSONEq(t, bodies["2"], schema.Body)
+
+	versions, err := client.ContentTypeSchemaListVersions("schema-id")
+	assert.NoError(t, err)
+	assert.Len(t, versions, 3)
+	for i, version := range versions {
+		assert.Equal(t, i+1, version.Version)
+	}
+
+	ops, err := client.ContentTypeSchemaDiffVersions("schema-id", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []PatchOperation{{Op: "add", Path: "/properties/image", Value: map[string]interface{}{"type": "object"}}}, ops)
+
+	requests = nil
+	result, err := client.ContentTypeSchemaRollback("schema-id", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, result.Version)
+	assert.Equal(t, map[string]interface{}{"body": bodies["1"], "version": float64(3)}, patch)
+	assert.Equal(t, []string{
+		"GET /content-type-schemas/schema-id",
+		"GET /content-type-schemas/schema-id/1",
+		"PATCH /content-type-schemas/schema-id",
+	}, requests)
+}
+
+func TestContentTypeSchemaRollbackConflict(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/content-type-schemas/schema-id":
+			w.Write([]byte(`{"id": "schema-id", "version": 3, "body": "{\"title\": \"new\"}"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/content-type-schemas/schema-id/1":
+			w.Write([]byte(`{"id": "schema-id", "version": 1, "body": "{\"title\": \"old\"}"}`))
+		case r.Method == http.MethodPatch:
+			// Someone else updated the schema after it was fetched
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"errors": [{"message": "version conflict"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	_, err := client.ContentTypeSchemaRollback("schema-id", 1)
+	assert.True(t, IsConflict(err))
+}
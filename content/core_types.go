@@ -0,0 +1,99 @@
+package content
+
+// CoreSchemaID is the id of the Amplience core schema, which defines the types
+// below and the base of every content type schema.
+const CoreSchemaID = "http://bigcontent.io/cms/schema/v1/core"
+
+// Schemas of the core types, as used in the `_meta` of their values and in
+// `$ref`s of content type schemas.
+const (
+	ContentSchema          = CoreSchemaID + "#/definitions/content"
+	ContentLinkSchema      = CoreSchemaID + "#/definitions/content-link"
+	ContentReferenceSchema = CoreSchemaID + "#/definitions/content-reference"
+	ImageLinkSchema        = CoreSchemaID + "#/definitions/image-link"
+	VideoLinkSchema        = CoreSchemaID + "#/definitions/video-link"
+	LocalizedValueSchema   = CoreSchemaID + "#/definitions/localized-value"
+)
+
+// ContentLink links to another content item, which is included when the
+// content is delivered.
+type ContentLink struct {
+	Meta        ContentMeta `json:"_meta"`
+	ContentType string      `json:"contentType"`
+	ID          string      `json:"id"`
+}
+
+// NewContentLink returns a link to the content item with the given id and
+// content type schema.
+func NewContentLink(contentType string, id string) ContentLink {
+	return ContentLink{
+		Meta:        ContentMeta{Schema: ContentLinkSchema},
+		ContentType: contentType,
+		ID:          id,
+	}
+}
+
+// ContentReference refers to another content item by id, without including it
+// when the content is delivered.
+type ContentReference struct {
+	Meta        ContentMeta `json:"_meta"`
+	ContentType string      `json:"contentType"`
+	ID          string      `json:"id"`
+}
+
+// NewContentReference returns a reference to the content item with the given
+// id and content type schema.
+func NewContentReference(contentType string, id string) ContentReference {
+	return ContentReference{
+		Meta:        ContentMeta{Schema: ContentReferenceSchema},
+		ContentType: contentType,
+		ID:          id,
+	}
+}
+
+// MediaLink links to an image or video in Content Hub.
+type MediaLink struct {
+	Meta        ContentMeta `json:"_meta"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Endpoint    string      `json:"endpoint"`
+	DefaultHost string      `json:"defaultHost"`
+	MediaType   string      `json:"mediaType,omitempty"`
+}
+
+// LocalizedValue holds a value per locale.
+type LocalizedValue[T any] struct {
+	Meta   ContentMeta         `json:"_meta"`
+	Values []LocalizedEntry[T] `json:"values"`
+}
+
+// LocalizedEntry is the value of a LocalizedValue for a single locale.
+type LocalizedEntry[T any] struct {
+	Locale string `json:"locale"`
+	Value  T      `json:"value"`
+}
+
+// Get returns the value for the given locale.
+func (v LocalizedValue[T]) Get(locale string) (T, bool) {
+	for _, entry := range v.Values {
+		if entry.Locale == locale {
+			return entry.Value, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Set sets the value for the given locale.
+func (v *LocalizedValue[T]) Set(locale string, value T) {
+	if v.Meta.Schema == "" {
+		v.Meta.Schema = LocalizedValueSchema
+	}
+	for i, entry := range v.Values {
+		if entry.Locale == locale {
+			v.Values[i].Value = value
+			return
+		}
+	}
+	v.Values = append(v.Values, LocalizedEntry[T]{Locale: locale, Value: value})
+}
@@ -0,0 +1,180 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const maxDeliveryKeyLength = 150
+
+var (
+	// ErrInvalidDeliveryKey is returned for delivery keys which do not match
+	// the format required by Amplience.
+	ErrInvalidDeliveryKey = errors.New("invalid delivery key")
+
+	// ErrDeliveryKeyConflict is returned when setting a delivery key which is
+	// already used by another content item in the hub.
+	ErrDeliveryKeyConflict = errors.New("delivery key is already in use")
+)
+
+// ValidateDeliveryKey validates a delivery key against the format rules of
+// Amplience: at most 150 characters, consisting of letters, digits, `-`, `_`
+// and `/`, where a `/` is not allowed at the start or end, or twice in a row.
+func ValidateDeliveryKey(key string) error {
+	switch {
+	case key == "":
+		return fmt.Errorf("%w: must not be empty", ErrInvalidDeliveryKey)
+	case len(key) > maxDeliveryKeyLength:
+		return fmt.Errorf("%w: must be at most %d characters", ErrInvalidDeliveryKey, maxDeliveryKeyLength)
+	case strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/"):
+		return fmt.Errorf("%w: must not start or end with a /", ErrInvalidDeliveryKey)
+	case strings.Contains(key, "//"):
+		return fmt.Errorf("%w: must not contain //", ErrInvalidDeliveryKey)
+	}
+
+	for _, c := range key {
+		valid := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '/'
+		if !valid {
+			return fmt.Errorf("%w: invalid character %q", ErrInvalidDeliveryKey, c)
+		}
+	}
+	return nil
+}
+
+// DeliveryKey returns the delivery key of the content item, which is stored in
+// the `_meta` of its body.
+func (item ContentItem) DeliveryKey() string {
+	meta, _ := item.Body["_meta"].(map[string]interface{})
+	key, _ := meta["deliveryKey"].(string)
+	return key
+}
+
+// SetDeliveryKey sets the delivery key in the `_meta` of the body. An empty key
+// removes the delivery key.
+func (input *ContentItemInput) SetDeliveryKey(key string) {
+	if input.Body == nil {
+		input.Body = map[string]interface{}{}
+	}
+
+	meta, ok := input.Body["_meta"].(map[string]interface{})
+	if !ok {
+		if key == "" {
+			return
+		}
+		meta = map[string]interface{}{}
+		input.Body["_meta"] = meta
+	}
+
+	if key == "" {
+		delete(meta, "deliveryKey")
+	} else {
+		meta["deliveryKey"] = key
+	}
+}
+
+// ContentItemFindByDeliveryKey returns the active content item in the hub with
+// the given delivery key. Since this searches all content repositories of the
+// hub it can be slow for large hubs. An error for which IsNotFound returns true
+// is returned when no item has the delivery key.
+func (client *Client) ContentItemFindByDeliveryKey(hubID string, key string) (ContentItem, error) {
+	return client.ContentItemFindByDeliveryKeyWithContext(context.Background(), hubID, key)
+}
+
+// ContentItemFindByDeliveryKeyWithContext is the same as ContentItemFindByDeliveryKey with a custom context
+func (client *Client) ContentItemFindByDeliveryKeyWithContext(ctx context.Context, hubID string, key string) (ContentItem, error) {
+	for repository, err := range client.ContentRepositoryIterateWithContext(ctx, hubID, PaginationParameters{}) {
+		if err != nil {
+			return ContentItem{}, err
+		}
+
+		parameters := ContentItemPaginationParameters{Status: StatusActive}
+		for item, err := range client.ContentItemIterateWithContext(ctx, repository.ID, parameters) {
+			if err != nil {
+				return ContentItem{}, err
+			}
+			if item.DeliveryKey() == key {
+				return item, nil
+			}
+		}
+	}
+	return ContentItem{}, fmt.Errorf("%w: no content item with delivery key %s", ErrNotFound, key)
+}
+
+// ContentItemDeliveryKeyCollisions returns the delivery keys which are used by
+// more than one active content item in the hub, together with those items.
+func (client *Client) ContentItemDeliveryKeyCollisions(hubID string) (map[string][]ContentItem, error) {
+	return client.ContentItemDeliveryKeyCollisionsWithContext(context.Background(), hubID)
+}
+
+// ContentItemDeliveryKeyCollisionsWithContext is the same as ContentItemDeliveryKeyCollisions with a custom context
+func (client *Client) ContentItemDeliveryKeyCollisionsWithContext(ctx context.Context, hubID string) (map[string][]ContentItem, error) {
+	items, err := client.contentItemsByDeliveryKey(ctx, hubID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string][]ContentItem{}
+	for key, matches := range items {
+		if len(matches) > 1 {
+			result[key] = matches
+		}
+	}
+	return result, nil
+}
+
+// contentItemsByDeliveryKey returns the active content items of the hub which
+// have a delivery key, by delivery key.
+func (client *Client) contentItemsByDeliveryKey(ctx context.Context, hubID string) (map[string][]ContentItem, error) {
+	result := map[string][]ContentItem{}
+	for repository, err := range client.ContentRepositoryIterateWithContext(ctx, hubID, PaginationParameters{}) {
+		if err != nil {
+			return nil, err
+		}
+
+		parameters := ContentItemPaginationParameters{Status: StatusActive}
+		for item, err := range client.ContentItemIterateWithContext(ctx, repository.ID, parameters) {
+			if err != nil {
+				return nil, err
+			}
+			if key := item.DeliveryKey(); key != "" {
+				result[key] = append(result[key], item)
+			}
+		}
+	}
+	return result, nil
+}
+
+// ContentItemSetDeliveryKey sets the delivery key of a content item after
+// validating it, and checking that no other item in the hub uses it. An empty
+// key removes the delivery key of the item.
+func (client *Client) ContentItemSetDeliveryKey(hubID string, id string, key string) (ContentItem, error) {
+	return client.ContentItemSetDeliveryKeyWithContext(context.Background(), hubID, id, key)
+}
+
+// ContentItemSetDeliveryKeyWithContext is the same as ContentItemSetDeliveryKey with a custom context
+func (client *Client) ContentItemSetDeliveryKeyWithContext(ctx context.Context, hubID string, id string, key string) (ContentItem, error) {
+	if key != "" {
+		if err := ValidateDeliveryKey(key); err != nil {
+			return ContentItem{}, err
+		}
+
+		// All matches are checked, since the key may already be used by both
+		// this item and another one.
+		items, err := client.contentItemsByDeliveryKey(ctx, hubID)
+		if err != nil {
+			return ContentItem{}, err
+		}
+		for _, existing := range items[key] {
+			if existing.ID != id {
+				return ContentItem{}, fmt.Errorf("%w: %s is used by content item %s", ErrDeliveryKeyConflict, key, existing.ID)
+			}
+		}
+	}
+
+	return client.ContentItemUpdateWithRetryWithContext(ctx, id, defaultUpdateAttempts, func(input *ContentItemInput) error {
+		input.SetDeliveryKey(key)
+		return nil
+	})
+}
@@ -0,0 +1,132 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDeliveryKey(t *testing.T) {
+	valid := []string{"home", "en/home-page", "a_b/c-d/E1"}
+	for _, key := range valid {
+		assert.NoError(t, ValidateDeliveryKey(key), key)
+	}
+
+	invalid := []string{"", "/home", "home/", "en//home", "home page", "héllo", strings.Repeat("a", 151)}
+	for _, key := range invalid {
+		assert.ErrorIs(t, ValidateDeliveryKey(key), ErrInvalidDeliveryKey, key)
+	}
+}
+
+func TestSetDeliveryKey(t *testing.T) {
+	input := ContentItemInput{}
+	input.SetDeliveryKey("home")
+	item := ContentItem{Body: input.Body}
+	assert.Equal(t, "home", item.DeliveryKey())
+
+	input.SetDeliveryKey("")
+	assert.Equal(t, "", item.DeliveryKey())
+	assert.Equal(t, map[string]interface{}{}, input.Body["_meta"])
+}
+
+// newDeliveryKeyTestClient returns a client for a hub with two repositories,
+// where the items a and c both use the delivery key `home`. The bodies of
+// patched items are added to patches.
+func newDeliveryKeyTestClient(t *testing.T, patches map[string]map[string]interface{}) *Client {
+	items := map[string]string{
+		"a": `{"id": "a", "version": 1, "body": {"_meta": {"deliveryKey": "home"}}}`,
+		"b": `{"id": "b", "version": 1, "body": {"_meta": {}}}`,
+		"c": `{"id": "c", "version": 1, "body": {"_meta": {"deliveryKey": "home"}}}`,
+		"d": `{"id": "d", "version": 1, "body": {"_meta": {"deliveryKey": "about"}}}`,
+	}
+	repositories := map[string][]string{"repo-1": {"a", "b"}, "repo-2": {"c", "d"}}
+
+	return newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+		switch {
+		case r.URL.Path == "/hubs/hub/content-repositories":
+			w.Write([]byte(`{"_embedded": {"content-repositories": [{"id": "repo-1"}, {"id": "repo-2"}]}, "page": {"number": 0, "totalPages": 1}}`))
+		case len(parts) == 3 && parts[0] == "content-repositories" && parts[2] == "content-items":
+			assert.Equal(t, "ACTIVE", r.URL.Query().Get("status"))
+			var result []string
+			for _, id := range repositories[parts[1]] {
+				result = append(result, items[id])
+			}
+			fmt.Fprintf(w, `{"_embedded": {"content-items": [%s]}, "page": {"number": 0, "totalPages": 1}}`, strings.Join(result, ","))
+		case len(parts) == 2 && parts[0] == "content-items" && items[parts[1]] == "":
+			w.WriteHeader(http.StatusNotFound)
+		case len(parts) == 2 && parts[0] == "content-items" && r.Method == http.MethodGet:
+			w.Write([]byte(items[parts[1]]))
+		case len(parts) == 2 && parts[0] == "content-items" && r.Method == http.MethodPatch:
+			input := map[string]interface{}{}
+			data, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(data, &input))
+			body, _ := input["body"].(map[string]interface{})
+			patches[parts[1]] = body
+			data, _ = json.Marshal(body)
+			fmt.Fprintf(w, `{"id": %q, "version": 2, "body": %s}`, parts[1], data)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	})
+}
+
+func TestContentItemFindByDeliveryKey(t *testing.T) {
+	client := newDeliveryKeyTestClient(t, nil)
+
+	// Items in later repositories are found as well
+	item, err := client.ContentItemFindByDeliveryKey("hub", "about")
+	assert.NoError(t, err)
+	assert.Equal(t, "d", item.ID)
+
+	_, err = client.ContentItemFindByDeliveryKey("hub", "contact")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestContentItemDeliveryKeyCollisions(t *testing.T) {
+	client := newDeliveryKeyTestClient(t, nil)
+
+	collisions, err := client.ContentItemDeliveryKeyCollisions("hub")
+	assert.NoError(t, err)
+	assert.Len(t, collisions, 1)
+	assert.Len(t, collisions["home"], 2)
+	assert.Equal(t, "a", collisions["home"][0].ID)
+	assert.Equal(t, "c", collisions["home"][1].ID)
+}
+
+func TestContentItemSetDeliveryKey(t *testing.T) {
+	patches := map[string]map[string]interface{}{}
+	client := newDeliveryKeyTestClient(t, patches)
+
+	item, err := client.ContentItemSetDeliveryKey("hub", "b", "contact")
+	assert.NoError(t, err)
+	assert.Equal(t, "contact", item.DeliveryKey())
+	assert.Equal(t, map[string]interface{}{"deliveryKey": "contact"}, patches["b"]["_meta"])
+
+	// The key is used by another item
+	_, err = client.ContentItemSetDeliveryKey("hub", "b", "about")
+	assert.ErrorIs(t, err, ErrDeliveryKeyConflict)
+
+	// The item already has the key, but so does another one
+	_, err = client.ContentItemSetDeliveryKey("hub", "a", "home")
+	assert.ErrorIs(t, err, ErrDeliveryKeyConflict)
+	assert.Contains(t, err.Error(), "content item c")
+
+	_, err = client.ContentItemSetDeliveryKey("hub", "b", "en//home")
+	assert.ErrorIs(t, err, ErrInvalidDeliveryKey)
+
+	// Clearing a key does not check for conflicts, and removes it with the patch
+	_, err = client.ContentItemSetDeliveryKey("hub", "d", "")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"deliveryKey": nil}, patches["d"]["_meta"])
+
+	_, err = client.ContentItemSetDeliveryKey("hub", "missing", "")
+	assert.True(t, IsNotFound(err))
+
+	assert.Len(t, patches, 2)
+}
@@ -0,0 +1,75 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// PlannedRequest is a request which would have been made by a client in dry
+// run mode.
+type PlannedRequest struct {
+	Operation string          `json:"operation"`
+	Method    string          `json:"method"`
+	Endpoint  string          `json:"endpoint"`
+	Body      json.RawMessage `json:"body,omitempty"`
+}
+
+// Plan records the requests which would have been made by a client in dry run
+// mode. It is safe for concurrent use.
+type Plan struct {
+	mu       sync.Mutex
+	requests []PlannedRequest
+}
+
+// Requests returns the recorded requests in the order they were made.
+func (p *Plan) Requests() []PlannedRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]PlannedRequest(nil), p.requests...)
+}
+
+// Reset removes all recorded requests.
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = nil
+}
+
+// MarshalJSON encodes the plan as a list of requests.
+func (p *Plan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.Requests())
+}
+
+func (p *Plan) record(req *Request) {
+	planned := PlannedRequest{
+		Operation: req.Operation,
+		Method:    req.Method,
+		Endpoint:  req.Endpoint,
+	}
+	if len(req.Body) > 0 {
+		planned.Body = append(json.RawMessage(nil), req.Body...)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.requests = append(p.requests, planned)
+}
+
+// middleware returns a Middleware which records all requests that would
+// modify data instead of sending them. Read requests are still sent.
+func (p *Plan) middleware(next Doer) Doer {
+	return DoerFunc(func(ctx context.Context, req *Request) (*Response, error) {
+		if !req.modifiesData() {
+			return next.Do(ctx, req)
+		}
+		p.record(req)
+		return &Response{}, nil
+	})
+}
+
+// Plan returns the requests recorded by the client when it is in dry run
+// mode, or nil otherwise.
+func (client *Client) Plan() *Plan {
+	return client.plan
+}
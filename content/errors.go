@@ -0,0 +1,149 @@
+package content
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors which can be matched against an ErrorResponse using
+// errors.Is, e.g. `errors.Is(err, content.ErrNotFound)`.
+var (
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+	ErrValidation   = errors.New("validation failed")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// ErrorResponse is returned for all API calls which result in a 4xx or 5xx
+// response.
+type ErrorResponse struct {
+	Inner      error
+	StatusCode int
+	Errors     []ErrorObject `json:"errors"`
+}
+
+func (e *ErrorResponse) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, obj := range e.Errors {
+		if msg := obj.Error(); msg != "" {
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 && e.Inner != nil {
+		messages = append(messages, e.Inner.Error())
+	}
+
+	status := fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if len(messages) == 0 {
+		return status
+	}
+	return fmt.Sprintf("%s: %s", status, strings.Join(messages, "; "))
+}
+
+// Unwrap is used to make it work with errors.Is, errors.As.
+func (e *ErrorResponse) Unwrap() error {
+	return e.Inner
+}
+
+// Is reports whether the status code of the response matches the given
+// sentinel error.
+func (e *ErrorResponse) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// PropertyErrors returns the errors which were reported for the given
+// property.
+func (e *ErrorResponse) PropertyErrors(property string) []ErrorObject {
+	var result []ErrorObject
+	for _, obj := range e.Errors {
+		if obj.Property == property {
+			result = append(result, obj)
+		}
+	}
+	return result
+}
+
+// ErrorObject is a single error reported by the API. Entity, Property and
+// InvalidValue are only set when the error relates to a specific field.
+type ErrorObject struct {
+	Entity       string `json:"entity"`
+	Property     string `json:"property"`
+	InvalidValue string `json:"invalidValue"`
+	Message      string `json:"message"`
+}
+
+func (e ErrorObject) Error() string {
+	if e.Property != "" {
+		return fmt.Sprintf("%s: %s", e.Property, e.Message)
+	}
+	return e.Message
+}
+
+// UnmarshalJSON is a custom unmarshaller since the API returns the
+// invalidValue as whatever type was sent, not just as string.
+func (e *ErrorObject) UnmarshalJSON(data []byte) error {
+	type Alias ErrorObject
+	aux := struct {
+		*Alias
+		InvalidValue json.RawMessage `json:"invalidValue"`
+	}{
+		Alias: (*Alias)(e),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	e.InvalidValue = ""
+	if len(aux.InvalidValue) > 0 && string(aux.InvalidValue) != "null" {
+		if err := json.Unmarshal(aux.InvalidValue, &e.InvalidValue); err != nil {
+			e.InvalidValue = string(aux.InvalidValue)
+		}
+	}
+	return nil
+}
+
+// ErrorObjects returns the errors reported by the API if err is or wraps an
+// ErrorResponse.
+func ErrorObjects(err error) []ErrorObject {
+	var errResponse *ErrorResponse
+	if errors.As(err, &errResponse) {
+		return errResponse.Errors
+	}
+	return nil
+}
+
+// IsNotFound reports whether err is caused by a 404 Not Found response.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsConflict reports whether err is caused by a 409 Conflict response, which
+// is returned when updating an outdated version of a resource.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsValidationError reports whether err is caused by the API rejecting the
+// request input.
+func IsValidationError(err error) bool {
+	return errors.Is(err, ErrValidation)
+}
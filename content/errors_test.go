@@ -0,0 +1,52 @@
+package content
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorResponseIs(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &ErrorResponse{StatusCode: http.StatusConflict})
+
+	assert.True(t, IsConflict(err))
+	assert.False(t, IsNotFound(err))
+	assert.False(t, IsValidationError(err))
+	assert.True(t, errors.Is(err, ErrConflict))
+	assert.False(t, errors.Is(err, ErrNotFound))
+}
+
+func TestErrorResponseError(t *testing.T) {
+	err := &ErrorResponse{StatusCode: http.StatusNotFound}
+	assert.Equal(t, "404 Not Found", err.Error())
+
+	err = &ErrorResponse{
+		StatusCode: http.StatusBadRequest,
+		Errors: []ErrorObject{
+			{Property: "label", Message: "must not be empty"},
+			{Message: "invalid body"},
+		},
+	}
+	assert.Equal(t, "400 Bad Request: label: must not be empty; invalid body", err.Error())
+	assert.Len(t, err.PropertyErrors("label"), 1)
+	assert.Len(t, ErrorObjects(fmt.Errorf("wrapped: %w", err)), 2)
+}
+
+func TestErrorResponseFromAPI(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors": [{"entity": "ContentItem", "property": "version", "invalidValue": 3, "message": "outdated"}]}`))
+	})
+
+	_, err := client.ContentItemGet("item-id")
+	assert.True(t, IsValidationError(err))
+
+	objects := ErrorObjects(err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "ContentItem", objects[0].Entity)
+	assert.Equal(t, "version", objects[0].Property)
+	assert.Equal(t, "3", objects[0].InvalidValue)
+}
@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -60,25 +62,63 @@ func (r *ExtensionResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *ExtensionResults) Next(client *Client) (ExtensionResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *ExtensionResults) NextWithContext(ctx context.Context, client *Client) (ExtensionResults, error) {
+	result := ExtensionResults{}
+	err := client.followRelation(ctx, "ExtensionResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *ExtensionResults) Prev(client *Client) (ExtensionResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *ExtensionResults) PrevWithContext(ctx context.Context, client *Client) (ExtensionResults, error) {
+	result := ExtensionResults{}
+	err := client.followRelation(ctx, "ExtensionResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 func (client *Client) ExtensionCreate(hubID string, input ExtensionInput) (Extension, error) {
+	return client.ExtensionCreateWithContext(context.Background(), hubID, input)
+}
+
+func (client *Client) ExtensionCreateWithContext(ctx context.Context, hubID string, input ExtensionInput) (Extension, error) {
 	result := Extension{}
 	body, err := json.Marshal(input)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/hubs/%s/extensions", hubID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "ExtensionCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ExtensionGet(id string) (Extension, error) {
+	return client.ExtensionGetWithContext(context.Background(), id)
+}
+
+func (client *Client) ExtensionGetWithContext(ctx context.Context, id string) (Extension, error) {
 	endpoint := fmt.Sprintf("/extensions/%s", id)
 	result := Extension{}
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ExtensionGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ExtensionUpdate(current Extension, input ExtensionInput) (Extension, error) {
+	return client.ExtensionUpdateWithContext(context.Background(), current, input)
+}
+
+func (client *Client) ExtensionUpdateWithContext(ctx context.Context, current Extension, input ExtensionInput) (Extension, error) {
 	result := Extension{}
 
 	body, err := createUpdatePatch(
@@ -105,37 +145,51 @@ func (client *Client) ExtensionUpdate(current Extension, input ExtensionInput) (
 	}
 
 	endpoint := fmt.Sprintf("/extensions/%s", current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "ExtensionUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) ExtensionDelete(id string) error {
+	return client.ExtensionDeleteWithContext(context.Background(), id)
+}
+
+func (client *Client) ExtensionDeleteWithContext(ctx context.Context, id string) error {
 	endpoint := fmt.Sprintf("/extensions/%s", id)
-	return client.request(http.MethodDelete, endpoint, nil, nil)
+	return client.request(ctx, "ExtensionDelete", http.MethodDelete, endpoint, nil, nil)
 }
 
 func (client *Client) ExtensionList(hubID string, parameters PaginationParameters) (ExtensionResults, error) {
+	return client.ExtensionListWithContext(context.Background(), hubID, parameters)
+}
+
+func (client *Client) ExtensionListWithContext(ctx context.Context, hubID string, parameters PaginationParameters) (ExtensionResults, error) {
 	result := ExtensionResults{}
 	endpoint := fmt.Sprintf("/hubs/%s/extensions?%s", hubID, PaginationQueryString(parameters))
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "ExtensionList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) ExtensionGetAll(hubID string) ([]Extension, error) {
+	return client.ExtensionGetAllWithContext(context.Background(), hubID)
+}
+
+func (client *Client) ExtensionGetAllWithContext(ctx context.Context, hubID string) ([]Extension, error) {
 	parameters := PaginationParameters{}
-	response, err := client.ExtensionList(hubID, parameters)
-
-	var result []Extension
-	result = append(result, response.Items...)
-
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.ExtensionList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+	return Collect(client.ExtensionIterateWithContext(ctx, hubID, parameters))
+}
 
-	return result, err
+// ExtensionIterate returns an iterator over all extensions within the given
+// hub. Pages are fetched as needed, so iteration can be stopped early. The Page
+// parameter is ignored.
+func (client *Client) ExtensionIterate(hubID string, parameters PaginationParameters) iter.Seq2[Extension, error] {
+	return client.ExtensionIterateWithContext(context.Background(), hubID, parameters)
+}
+
+// ExtensionIterateWithContext is the same as ExtensionIterate with a custom context
+func (client *Client) ExtensionIterateWithContext(ctx context.Context, hubID string, parameters PaginationParameters) iter.Seq2[Extension, error] {
+	return Iterate(func(page int) ([]Extension, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.ExtensionListWithContext(ctx, hubID, parameters)
+		return response.Items, response.Page, err
+	})
 }
@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -39,57 +41,104 @@ func (r *FolderResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *FolderResults) Next(client *Client) (FolderResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *FolderResults) NextWithContext(ctx context.Context, client *Client) (FolderResults, error) {
+	result := FolderResults{}
+	err := client.followRelation(ctx, "FolderResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *FolderResults) Prev(client *Client) (FolderResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *FolderResults) PrevWithContext(ctx context.Context, client *Client) (FolderResults, error) {
+	result := FolderResults{}
+	err := client.followRelation(ctx, "FolderResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 func (client *Client) FolderCreate(repositoryID string, input FolderInput) (FolderInput, error) {
+	return client.FolderCreateWithContext(context.Background(), repositoryID, input)
+}
+
+func (client *Client) FolderCreateWithContext(ctx context.Context, repositoryID string, input FolderInput) (FolderInput, error) {
 	result := FolderInput{}
 	body, err := json.Marshal(input)
 	if err != nil {
 		return result, err
 	}
 	endpoint := fmt.Sprintf("/content-repositories/%s/folders", repositoryID)
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "FolderCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) FolderGet(id string) (Folder, error) {
+	return client.FolderGetWithContext(context.Background(), id)
+}
+
+func (client *Client) FolderGetWithContext(ctx context.Context, id string) (Folder, error) {
 	endpoint := fmt.Sprintf("/folders/%s", id)
 	result := Folder{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "FolderGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) FolderDelete(id string) (Folder, error) {
+	return client.FolderDeleteWithContext(context.Background(), id)
+}
+
+func (client *Client) FolderDeleteWithContext(ctx context.Context, id string) (Folder, error) {
 	endpoint := fmt.Sprintf("/folders/%s", id)
 	result := Folder{}
 
-	err := client.request(http.MethodDelete, endpoint, nil, &result)
+	err := client.request(ctx, "FolderDelete", http.MethodDelete, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) FolderList(repositoryID string, parameters PaginationParameters) (FolderResults, error) {
+	return client.FolderListWithContext(context.Background(), repositoryID, parameters)
+}
+
+func (client *Client) FolderListWithContext(ctx context.Context, repositoryID string, parameters PaginationParameters) (FolderResults, error) {
 	result := FolderResults{}
 	endpoint := fmt.Sprintf("/content-repositories/%s/folders?%s", repositoryID, PaginationQueryString(parameters))
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "FolderList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) FolderGetAll(hubID string) ([]Folder, error) {
-	parameters := PaginationParameters{}
-
-	response, err := client.FolderList(hubID, parameters)
+	return client.FolderGetAllWithContext(context.Background(), hubID)
+}
 
-	var result []Folder
-	result = append(result, response.Items...)
+func (client *Client) FolderGetAllWithContext(ctx context.Context, hubID string) ([]Folder, error) {
+	parameters := PaginationParameters{}
+	return Collect(client.FolderIterateWithContext(ctx, hubID, parameters))
+}
 
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.FolderList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+// FolderIterate returns an iterator over all folders within the given Content
+// Repository. Pages are fetched as needed, so iteration can be stopped early.
+// The Page parameter is ignored.
+func (client *Client) FolderIterate(repositoryID string, parameters PaginationParameters) iter.Seq2[Folder, error] {
+	return client.FolderIterateWithContext(context.Background(), repositoryID, parameters)
+}
 
-	return result, err
+// FolderIterateWithContext is the same as FolderIterate with a custom context
+func (client *Client) FolderIterateWithContext(ctx context.Context, repositoryID string, parameters PaginationParameters) iter.Seq2[Folder, error] {
+	return Iterate(func(page int) ([]Folder, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.FolderListWithContext(ctx, repositoryID, parameters)
+		return response.Items, response.Page, err
+	})
 }
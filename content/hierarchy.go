@@ -0,0 +1,238 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+var (
+	// ErrSkipChildren can be returned by the visitor of ContentItemNode.Walk
+	// to skip the children of a node.
+	ErrSkipChildren = errors.New("skip children")
+
+	// ErrHierarchyCycle is returned when moving a content item below itself
+	ErrHierarchyCycle = errors.New("content item can not be moved below itself")
+)
+
+// Hierarchy returns the position of the content item in a hierarchy, or nil
+// when the item is not part of a hierarchy.
+func (item ContentItem) Hierarchy() *ContentHierarchy {
+	meta, _ := item.Body["_meta"].(map[string]interface{})
+	hierarchy, ok := meta["hierarchy"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := &ContentHierarchy{}
+	result.Root, _ = hierarchy["root"].(bool)
+	result.ParentID, _ = hierarchy["parentId"].(string)
+	return result
+}
+
+// SetHierarchyParent places the content item below the given parent, by
+// setting the hierarchy in the `_meta` of the body.
+func (input *ContentItemInput) SetHierarchyParent(parentID string) {
+	if input.Body == nil {
+		input.Body = map[string]interface{}{}
+	}
+	meta, ok := input.Body["_meta"].(map[string]interface{})
+	if !ok {
+		meta = map[string]interface{}{}
+		input.Body["_meta"] = meta
+	}
+	meta["hierarchy"] = map[string]interface{}{
+		"root":     false,
+		"parentId": parentID,
+	}
+}
+
+// ContentItemListChildren lists the direct hierarchy children of a content
+// item
+func (client *Client) ContentItemListChildren(id string, parameters PaginationParameters) (ContentItemResults, error) {
+	return client.ContentItemListChildrenWithContext(context.Background(), id, parameters)
+}
+
+// ContentItemListChildrenWithContext is the same as ContentItemListChildren with a custom context
+func (client *Client) ContentItemListChildrenWithContext(ctx context.Context, id string, parameters PaginationParameters) (ContentItemResults, error) {
+	result := ContentItemResults{}
+	endpoint := fmt.Sprintf("/content-items/%s/hierarchy/children?%s", id, PaginationQueryString(parameters))
+
+	err := client.request(ctx, "ContentItemListChildren", http.MethodGet, endpoint, nil, &result)
+	return result, err
+}
+
+// ContentItemChildrenIterate returns an iterator over the direct hierarchy
+// children of a content item.
+func (client *Client) ContentItemChildrenIterate(id string) iter.Seq2[ContentItem, error] {
+	return client.ContentItemChildrenIterateWithContext(context.Background(), id)
+}
+
+// ContentItemChildrenIterateWithContext is the same as ContentItemChildrenIterate with a custom context
+func (client *Client) ContentItemChildrenIterateWithContext(ctx context.Context, id string) iter.Seq2[ContentItem, error] {
+	return Iterate(func(page int) ([]ContentItem, PageInformation, error) {
+		response, err := client.ContentItemListChildrenWithContext(ctx, id, PaginationParameters{Page: page})
+		return response.Items, response.Page, err
+	})
+}
+
+// ContentItemAncestors returns the ancestors of a content item in its
+// hierarchy, starting with the root and ending with the direct parent.
+func (client *Client) ContentItemAncestors(id string) ([]ContentItem, error) {
+	return client.ContentItemAncestorsWithContext(context.Background(), id)
+}
+
+// ContentItemAncestorsWithContext is the same as ContentItemAncestors with a custom context
+func (client *Client) ContentItemAncestorsWithContext(ctx context.Context, id string) ([]ContentItem, error) {
+	item, err := client.ContentItemGetWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []ContentItem
+	seen := map[string]bool{id: true}
+	for hierarchy := item.Hierarchy(); hierarchy != nil && hierarchy.ParentID != ""; hierarchy = item.Hierarchy() {
+		if seen[hierarchy.ParentID] {
+			return nil, fmt.Errorf("%w: %s is its own ancestor", ErrHierarchyCycle, hierarchy.ParentID)
+		}
+		seen[hierarchy.ParentID] = true
+
+		if item, err = client.ContentItemGetWithContext(ctx, hierarchy.ParentID); err != nil {
+			return nil, err
+		}
+		result = append([]ContentItem{item}, result...)
+	}
+	return result, nil
+}
+
+// ContentItemNode is a content item in a hierarchy, together with its children
+type ContentItemNode struct {
+	Item     ContentItem
+	Children []*ContentItemNode
+}
+
+// Walk calls visit for the node and all nodes below it, depth-first, with the
+// depth relative to this node. When visit returns ErrSkipChildren the children
+// of that node are skipped, any other error stops the walk and is returned.
+func (n *ContentItemNode) Walk(visit func(node *ContentItemNode, depth int) error) error {
+	err := n.walk(visit, 0)
+	if errors.Is(err, ErrSkipChildren) {
+		return nil
+	}
+	return err
+}
+
+func (n *ContentItemNode) walk(visit func(node *ContentItemNode, depth int) error, depth int) error {
+	if err := visit(n, depth); err != nil {
+		return err
+	}
+	for _, child := range n.Children {
+		if err := child.walk(visit, depth+1); err != nil && !errors.Is(err, ErrSkipChildren) {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find returns the node of the content item with the given id
+func (n *ContentItemNode) Find(id string) *ContentItemNode {
+	var result *ContentItemNode
+	n.Walk(func(node *ContentItemNode, depth int) error {
+		if node.Item.ID == id {
+			result = node
+			return errFound
+		}
+		return nil
+	})
+	return result
+}
+
+var errFound = errors.New("found")
+
+// ContentItemTree fetches the content item with the given id and all items
+// below it in the hierarchy.
+func (client *Client) ContentItemTree(id string) (*ContentItemNode, error) {
+	return client.ContentItemTreeWithContext(context.Background(), id)
+}
+
+// ContentItemTreeWithContext is the same as ContentItemTree with a custom context
+func (client *Client) ContentItemTreeWithContext(ctx context.Context, id string) (*ContentItemNode, error) {
+	item, err := client.ContentItemGetWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	root := &ContentItemNode{Item: item}
+	return root, client.fetchChildren(ctx, root, map[string]bool{id: true})
+}
+
+func (client *Client) fetchChildren(ctx context.Context, node *ContentItemNode, seen map[string]bool) error {
+	for item, err := range client.ContentItemChildrenIterateWithContext(ctx, node.Item.ID) {
+		if err != nil {
+			return err
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("%w: %s is its own ancestor", ErrHierarchyCycle, item.ID)
+		}
+		seen[item.ID] = true
+
+		child := &ContentItemNode{Item: item}
+		node.Children = append(node.Children, child)
+		if err := client.fetchChildren(ctx, child, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ContentItemCreateChild creates a new content item below the given parent,
+// in the content repository of the parent.
+func (client *Client) ContentItemCreateChild(parentID string, input ContentItemInput) (ContentItem, error) {
+	return client.ContentItemCreateChildWithContext(context.Background(), parentID, input)
+}
+
+// ContentItemCreateChildWithContext is the same as ContentItemCreateChild with a custom context
+func (client *Client) ContentItemCreateChildWithContext(ctx context.Context, parentID string, input ContentItemInput) (ContentItem, error) {
+	parent, err := client.ContentItemGetWithContext(ctx, parentID)
+	if err != nil {
+		return ContentItem{}, err
+	}
+
+	// Copy the body, so the input of the caller is not modified
+	if input.Body, err = copyBody(input.Body); err != nil {
+		return ContentItem{}, err
+	}
+	input.SetHierarchyParent(parentID)
+	return client.ContentItemCreateWithContext(ctx, parent.ContentRepositoryID, input)
+}
+
+// ContentItemMove moves a content item, together with all items below it, to
+// a new parent. An error wrapping ErrHierarchyCycle is returned when the new
+// parent is part of the moved subtree.
+func (client *Client) ContentItemMove(id string, parentID string) (ContentItem, error) {
+	return client.ContentItemMoveWithContext(context.Background(), id, parentID)
+}
+
+// ContentItemMoveWithContext is the same as ContentItemMove with a custom context
+func (client *Client) ContentItemMoveWithContext(ctx context.Context, id string, parentID string) (ContentItem, error) {
+	if id == parentID {
+		return ContentItem{}, ErrHierarchyCycle
+	}
+
+	ancestors, err := client.ContentItemAncestorsWithContext(ctx, parentID)
+	if err != nil {
+		return ContentItem{}, err
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID == id {
+			return ContentItem{}, ErrHierarchyCycle
+		}
+	}
+
+	// The children refer to the moved item, so only its parent is changed
+	return client.ContentItemUpdateWithRetryWithContext(ctx, id, defaultUpdateAttempts, func(input *ContentItemInput) error {
+		input.SetHierarchyParent(parentID)
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package content
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentItemTree(t *testing.T) {
+	parents := map[string]string{"b": "root", "c": "root", "d": "b"}
+	item := func(id string) string {
+		hierarchy := `{"root": true}`
+		if parent, ok := parents[id]; ok {
+			hierarchy = fmt.Sprintf(`{"root": false, "parentId": %q}`, parent)
+		}
+		return fmt.Sprintf(`{"id": %q, "body": {"_meta": {"hierarchy": %s}}}`, id, hierarchy)
+	}
+
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Split(strings.TrimPrefix(r.URL.Path, "/content-items/"), "/")[0]
+		if !strings.HasSuffix(r.URL.Path, "/hierarchy/children") {
+			w.Write([]byte(item(id)))
+			return
+		}
+
+		var children []string
+		for _, child := range []string{"b", "c", "d"} {
+			if parents[child] == id {
+				children = append(children, item(child))
+			}
+		}
+		fmt.Fprintf(w, `{"_embedded": {"content-items": [%s]}, "page": {"number": 0, "totalPages": 1}}`, strings.Join(children, ","))
+	})
+
+	tree, err := client.ContentItemTree("root")
+	assert.NoError(t, err)
+
+	var visited []string
+	err = tree.Walk(func(node *ContentItemNode, depth int) error {
+		visited = append(visited, fmt.Sprintf("%s:%d", node.Item.ID, depth))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"root:0", "b:1", "d:2", "c:1"}, visited)
+
+	visited = nil
+	tree.Walk(func(node *ContentItemNode, depth int) error {
+		visited = append(visited, node.Item.ID)
+		if node.Item.ID == "b" {
+			return ErrSkipChildren
+		}
+		return nil
+	})
+	assert.Equal(t, []string{"root", "b", "c"}, visited)
+	assert.Equal(t, "b", tree.Find("d").Item.Hierarchy().ParentID)
+
+	ancestors, err := client.ContentItemAncestors("d")
+	assert.NoError(t, err)
+	assert.Len(t, ancestors, 2)
+	assert.Equal(t, "root", ancestors[0].ID)
+
+	_, err = client.ContentItemMove("b", "d")
+	assert.ErrorIs(t, err, ErrHierarchyCycle)
+}
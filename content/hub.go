@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 )
 
@@ -100,37 +102,136 @@ func (r *HubResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *HubResults) Next(client *Client) (HubResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *HubResults) NextWithContext(ctx context.Context, client *Client) (HubResults, error) {
+	result := HubResults{}
+	err := client.followRelation(ctx, "HubResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *HubResults) Prev(client *Client) (HubResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *HubResults) PrevWithContext(ctx context.Context, client *Client) (HubResults, error) {
+	result := HubResults{}
+	err := client.followRelation(ctx, "HubResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
+// GetContentRepositories returns the first page of Content Repositories in the hub
+func (r *Hub) GetContentRepositories(client *Client) (ContentRepositoryResults, error) {
+	return r.GetContentRepositoriesWithContext(context.Background(), client)
+}
+
+// GetContentRepositoriesWithContext is the same as GetContentRepositories with a custom context
+func (r *Hub) GetContentRepositoriesWithContext(ctx context.Context, client *Client) (ContentRepositoryResults, error) {
+	result := ContentRepositoryResults{}
+	err := client.followRelation(ctx, "Hub.GetContentRepositories", r.Links, "content-repositories", &result)
+	return result, err
+}
+
+// GetContentTypeSchemas returns the first page of Content Type Schemas in the hub
+func (r *Hub) GetContentTypeSchemas(client *Client) (ContentTypeSchemaResults, error) {
+	return r.GetContentTypeSchemasWithContext(context.Background(), client)
+}
+
+// GetContentTypeSchemasWithContext is the same as GetContentTypeSchemas with a custom context
+func (r *Hub) GetContentTypeSchemasWithContext(ctx context.Context, client *Client) (ContentTypeSchemaResults, error) {
+	result := ContentTypeSchemaResults{}
+	err := client.followRelation(ctx, "Hub.GetContentTypeSchemas", r.Links, "content-type-schemas", &result)
+	return result, err
+}
+
+// GetContentTypes returns the first page of Content Types in the hub
+func (r *Hub) GetContentTypes(client *Client) (ContentTypeResults, error) {
+	return r.GetContentTypesWithContext(context.Background(), client)
+}
+
+// GetContentTypesWithContext is the same as GetContentTypes with a custom context
+func (r *Hub) GetContentTypesWithContext(ctx context.Context, client *Client) (ContentTypeResults, error) {
+	result := ContentTypeResults{}
+	err := client.followRelation(ctx, "Hub.GetContentTypes", r.Links, "content-types", &result)
+	return result, err
+}
+
+// GetWebhooks returns the first page of webhooks in the hub
+func (r *Hub) GetWebhooks(client *Client) (WebhookResults, error) {
+	return r.GetWebhooksWithContext(context.Background(), client)
+}
+
+// GetWebhooksWithContext is the same as GetWebhooks with a custom context
+func (r *Hub) GetWebhooksWithContext(ctx context.Context, client *Client) (WebhookResults, error) {
+	result := WebhookResults{}
+	err := client.followRelation(ctx, "Hub.GetWebhooks", r.Links, "webhooks", &result)
+	return result, err
+}
+
+// GetExtensions returns the first page of extensions in the hub
+func (r *Hub) GetExtensions(client *Client) (ExtensionResults, error) {
+	return r.GetExtensionsWithContext(context.Background(), client)
+}
+
+// GetExtensionsWithContext is the same as GetExtensions with a custom context
+func (r *Hub) GetExtensionsWithContext(ctx context.Context, client *Client) (ExtensionResults, error) {
+	result := ExtensionResults{}
+	err := client.followRelation(ctx, "Hub.GetExtensions", r.Links, "extensions", &result)
+	return result, err
+}
+
 func (client *Client) HubList(parameters PaginationParameters) (HubResults, error) {
+	return client.HubListWithContext(context.Background(), parameters)
+}
+
+func (client *Client) HubListWithContext(ctx context.Context, parameters PaginationParameters) (HubResults, error) {
 	parameters.Sort = "" // Sort is not supported.
 	result := HubResults{}
 	endpoint := fmt.Sprintf("/hubs?%s", PaginationQueryString(parameters))
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "HubList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) HubGetAll() ([]Hub, error) {
-	parameters := PaginationParameters{}
-
-	response, err := client.HubList(parameters)
+	return client.HubGetAllWithContext(context.Background())
+}
 
-	var result []Hub
-	result = append(result, response.Items...)
+func (client *Client) HubGetAllWithContext(ctx context.Context) ([]Hub, error) {
+	parameters := PaginationParameters{}
+	return Collect(client.HubIterateWithContext(ctx, parameters))
+}
 
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.HubList(parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+// HubIterate returns an iterator over all hubs. Pages are fetched as needed, so
+// iteration can be stopped early. The Page parameter is ignored.
+func (client *Client) HubIterate(parameters PaginationParameters) iter.Seq2[Hub, error] {
+	return client.HubIterateWithContext(context.Background(), parameters)
+}
 
-	return result, err
+// HubIterateWithContext is the same as HubIterate with a custom context
+func (client *Client) HubIterateWithContext(ctx context.Context, parameters PaginationParameters) iter.Seq2[Hub, error] {
+	return Iterate(func(page int) ([]Hub, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.HubListWithContext(ctx, parameters)
+		return response.Items, response.Page, err
+	})
 }
 
 // HubPatch will update hub settings. Note that if any settings are not provided they will be ignored during the
 // patch, so they will continue existing.
 func (client *Client) HubPatch(id string, input HubUpdateInput) (Hub, error) {
+	return client.HubPatchWithContext(context.Background(), id, input)
+}
+
+// HubPatchWithContext is the same as HubPatch with a custom context
+func (client *Client) HubPatchWithContext(ctx context.Context, id string, input HubUpdateInput) (Hub, error) {
 	endpoint := fmt.Sprintf("/hubs/%s", id)
 	result := Hub{}
 
@@ -139,14 +240,18 @@ func (client *Client) HubPatch(id string, input HubUpdateInput) (Hub, error) {
 		return Hub{}, err
 	}
 
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "HubPatch", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) HubGet(id string) (Hub, error) {
+	return client.HubGetWithContext(context.Background(), id)
+}
+
+func (client *Client) HubGetWithContext(ctx context.Context, id string) (Hub, error) {
 	endpoint := fmt.Sprintf("/hubs/%s", id)
 	result := Hub{}
 
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "HubGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
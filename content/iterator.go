@@ -0,0 +1,45 @@
+package content
+
+import "iter"
+
+// Iterate returns an iterator over all items of a paginated list endpoint. The
+// fetch function is called lazily for every page, starting at page 0, until
+// the last page is reached or the caller stops iterating.
+//
+// When fetching a page fails the error is yielded once, after which the
+// iteration ends.
+func Iterate[T any](fetch func(page int) ([]T, PageInformation, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for page := 0; ; page++ {
+			items, info, err := fetch(page)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if page >= info.TotalPages-1 {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns all items of the iterator. It stops at the first error and
+// returns the items collected so far together with the error.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var result []T
+	for item, err := range seq {
+		if err != nil {
+			return result, err
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package content
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterate(t *testing.T) {
+	var fetched []int
+	fetch := func(page int) ([]int, PageInformation, error) {
+		fetched = append(fetched, page)
+		if page == 2 {
+			return nil, PageInformation{}, errors.New("page failed")
+		}
+		return []int{page * 2, page*2 + 1}, PageInformation{Number: page, TotalPages: 4}, nil
+	}
+
+	items, err := Collect(Iterate(fetch))
+	assert.EqualError(t, err, "page failed")
+	assert.Equal(t, []int{0, 1, 2, 3}, items)
+	assert.Equal(t, []int{0, 1, 2}, fetched)
+
+	fetched = nil
+	for item, err := range Iterate(fetch) {
+		assert.NoError(t, err)
+		if item == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0}, fetched)
+}
@@ -0,0 +1,126 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// PatchOperation is a single operation of a JSON Patch (RFC 6902)
+type PatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// MarshalJSON is a custom marshaller which always includes the value of add
+// and replace operations, also when it is null.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{o.Op, o.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{o.Op, o.Path, o.Value})
+}
+
+// DiffJSON returns the JSON Patch which changes the JSON representation of
+// from into that of to. Objects are compared per field and arrays per index,
+// so items inserted halfway an array result in replace operations for the
+// items after it.
+func DiffJSON(from interface{}, to interface{}) ([]PatchOperation, error) {
+	src, err := normalizeJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := normalizeJSON(to)
+	if err != nil {
+		return nil, err
+	}
+	return diffValues("", src, dst), nil
+}
+
+// normalizeJSON encodes and decodes a value, so it only contains the types
+// produced by encoding/json.
+func normalizeJSON(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encoding value to compare: %w", err)
+	}
+	var result interface{}
+	err = json.Unmarshal(data, &result)
+	return result, err
+}
+
+func diffValues(path string, from interface{}, to interface{}) []PatchOperation {
+	switch src := from.(type) {
+	case map[string]interface{}:
+		if dst, ok := to.(map[string]interface{}); ok {
+			return diffObjects(path, src, dst)
+		}
+	case []interface{}:
+		if dst, ok := to.([]interface{}); ok {
+			return diffArrays(path, src, dst)
+		}
+	}
+
+	if reflect.DeepEqual(from, to) {
+		return nil
+	}
+	return []PatchOperation{{Op: "replace", Path: path, Value: to}}
+}
+
+func diffObjects(path string, from map[string]interface{}, to map[string]interface{}) []PatchOperation {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, ok := from[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var result []PatchOperation
+	for _, key := range keys {
+		child := path + "/" + escapeJSONPointer(key)
+		src, inFrom := from[key]
+		dst, inTo := to[key]
+		switch {
+		case !inTo:
+			result = append(result, PatchOperation{Op: "remove", Path: child})
+		case !inFrom:
+			result = append(result, PatchOperation{Op: "add", Path: child, Value: dst})
+		default:
+			result = append(result, diffValues(child, src, dst)...)
+		}
+	}
+	return result
+}
+
+func diffArrays(path string, from []interface{}, to []interface{}) []PatchOperation {
+	var result []PatchOperation
+	for i := 0; i < len(from) && i < len(to); i++ {
+		result = append(result, diffValues(fmt.Sprintf("%s/%d", path, i), from[i], to[i])...)
+	}
+	for i := len(from); i < len(to); i++ {
+		result = append(result, PatchOperation{Op: "add", Path: fmt.Sprintf("%s/%d", path, i), Value: to[i]})
+	}
+	// Remove from the end, so the indexes of the remaining items stay valid
+	for i := len(from) - 1; i >= len(to); i-- {
+		result = append(result, PatchOperation{Op: "remove", Path: fmt.Sprintf("%s/%d", path, i)})
+	}
+	return result
+}
+
+func escapeJSONPointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,47 @@
+package content
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffJSON(t *testing.T) {
+	from := map[string]interface{}{
+		"title": "Hello",
+		"a/b":   1,
+		"tags":  []string{"a", "b", "c"},
+		"nested": map[string]interface{}{
+			"keep":   true,
+			"change": "old",
+		},
+	}
+	to := map[string]interface{}{
+		"title": "Hello",
+		"tags":  []string{"a", "x"},
+		"nested": map[string]interface{}{
+			"keep":   true,
+			"change": nil,
+		},
+		"new": 2,
+	}
+
+	patch, err := DiffJSON(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, []PatchOperation{
+		{Op: "remove", Path: "/a~1b"},
+		{Op: "replace", Path: "/nested/change", Value: nil},
+		{Op: "add", Path: "/new", Value: float64(2)},
+		{Op: "replace", Path: "/tags/1", Value: "x"},
+		{Op: "remove", Path: "/tags/2"},
+	}, patch)
+
+	data, err := json.Marshal(patch[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"op": "replace", "path": "/nested/change", "value": null}`, string(data))
+
+	patch, err = DiffJSON(from, from)
+	assert.NoError(t, err)
+	assert.Empty(t, patch)
+}
@@ -0,0 +1,145 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrLinkNotFound is returned when following a relation which is not present
+// in the _links of a resource, e.g. the next page of the last page.
+var ErrLinkNotFound = errors.New("link not found")
+
+var templateExpression = regexp.MustCompile(`\{([^}]*)\}`)
+
+// Expand expands the (RFC 6570) URI template of the link with the given
+// variables. Variables which are not given are left out, so a link like
+// `/hubs/1/webhooks{?page,size,sort}` expands to `/hubs/1/webhooks` without
+// any variables.
+func (l Link) Expand(variables map[string]string) string {
+	return templateExpression.ReplaceAllStringFunc(l.Href, func(expression string) string {
+		return expandExpression(expression[1:len(expression)-1], variables)
+	})
+}
+
+// expandExpression expands a single template expression, without the braces.
+// The prefix (`:n`) modifier is supported, the explode (`*`) modifier has no
+// effect since all variables are strings.
+func expandExpression(expression string, variables map[string]string) string {
+	if len(expression) == 0 {
+		// Not a valid expression, so it is kept as is
+		return "{}"
+	}
+
+	var operator byte
+	if strings.IndexByte("+#./;?&", expression[0]) >= 0 {
+		operator = expression[0]
+		expression = expression[1:]
+	}
+
+	first, separator, named, ifEmpty, reserved := "", ",", false, "", false
+	switch operator {
+	case '+':
+		reserved = true
+	case '#':
+		first, reserved = "#", true
+	case '.':
+		first, separator = ".", "."
+	case '/':
+		first, separator = "/", "/"
+	case ';':
+		first, separator, named = ";", ";", true
+	case '?':
+		first, separator, named, ifEmpty = "?", "&", true, "="
+	case '&':
+		first, separator, named, ifEmpty = "&", "&", true, "="
+	}
+
+	var parts []string
+	for _, spec := range strings.Split(expression, ",") {
+		name := strings.TrimSuffix(spec, "*")
+		prefix := 0
+		if i := strings.IndexByte(name, ':'); i >= 0 {
+			prefix, _ = strconv.Atoi(name[i+1:])
+			name = name[:i]
+		}
+
+		value, ok := variables[name]
+		if !ok {
+			continue
+		}
+		if runes := []rune(value); prefix > 0 && len(runes) > prefix {
+			value = string(runes[:prefix])
+		}
+
+		switch {
+		case !named:
+			parts = append(parts, encodeTemplateValue(value, reserved))
+		case value == "":
+			parts = append(parts, name+ifEmpty)
+		default:
+			parts = append(parts, name+"="+encodeTemplateValue(value, reserved))
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return first + strings.Join(parts, separator)
+}
+
+// encodeTemplateValue percent-encodes all characters which are not
+// unreserved, and when reserved is set, keeps reserved characters and existing
+// percent-encoded triplets as well.
+func encodeTemplateValue(value string, reserved bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', strings.IndexByte("-._~", c) >= 0:
+			b.WriteByte(c)
+		case reserved && strings.IndexByte(":/?#[]@!$&'()*+,;=", c) >= 0:
+			b.WriteByte(c)
+		case reserved && c == '%' && i+2 < len(value) && isHex(value[i+1]) && isHex(value[i+2]):
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// Follow fetches the resource the link points to and decodes it into output.
+// Templated links are expanded without any variables.
+func (client *Client) Follow(link Link, output interface{}) error {
+	return client.FollowWithContext(context.Background(), link, output)
+}
+
+// FollowWithContext is the same as Follow with a custom context
+func (client *Client) FollowWithContext(ctx context.Context, link Link, output interface{}) error {
+	return client.follow(ctx, "Follow", link, output)
+}
+
+func (client *Client) follow(ctx context.Context, operation string, link Link, output interface{}) error {
+	if link.Href == "" {
+		return ErrLinkNotFound
+	}
+	return client.request(ctx, operation, http.MethodGet, link.Expand(nil), nil, output)
+}
+
+// followRelation follows the link with the given relation name.
+func (client *Client) followRelation(ctx context.Context, operation string, links map[string]Link, relation string, output interface{}) error {
+	link, ok := links[relation]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrLinkNotFound, relation)
+	}
+	return client.follow(ctx, operation, link, output)
+}
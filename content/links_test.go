@@ -0,0 +1,39 @@
+package content
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkExpand(t *testing.T) {
+	link := Link{Href: "https://api.amplience.net/v2/content/hubs/1/webhooks{?page,size,sort}", Templated: true}
+	assert.Equal(t, "https://api.amplience.net/v2/content/hubs/1/webhooks", link.Expand(nil))
+	assert.Equal(t,
+		"https://api.amplience.net/v2/content/hubs/1/webhooks?page=2&sort=createdDate%2Cdesc",
+		link.Expand(map[string]string{"page": "2", "sort": "createdDate,desc"}))
+
+	link = Link{Href: "/content-items/{id}{/version}{&status}", Templated: true}
+	assert.Equal(t, "/content-items/a%2Fb/3", link.Expand(map[string]string{"id": "a/b", "version": "3"}))
+	assert.Equal(t, "/content-items/a/b", Link{Href: "/content-items/{+id}"}.Expand(map[string]string{"id": "a/b"}))
+	assert.Equal(t, "/items?q=ab", Link{Href: "/items{?q:2}"}.Expand(map[string]string{"q": "abc"}))
+	assert.Equal(t, "/items/{}/1", Link{Href: "/items/{}{/id}"}.Expand(map[string]string{"id": "1"}))
+	assert.Equal(t, "/items", Link{Href: "/items{+}"}.Expand(nil))
+}
+
+func TestResultsNext(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/hubs", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page"))
+		w.Write([]byte(`{"_embedded": {"hubs": [{"id": "second"}]}, "_links": {}, "page": {"number": 1}}`))
+	})
+
+	first := HubResults{Links: map[string]Link{"next": {Href: client.url + "/hubs?page=1"}}}
+	second, err := first.Next(client)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", second.Items[0].ID)
+
+	_, err = second.Next(client)
+	assert.ErrorIs(t, err, ErrLinkNotFound)
+}
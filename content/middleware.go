@@ -0,0 +1,86 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrReadOnly is returned by the ReadOnly middleware for all requests which
+// would modify data.
+var ErrReadOnly = errors.New("client is read-only")
+
+// Request is a request to the Amplience API, as passed through the
+// middlewares of the Client.
+type Request struct {
+	// Operation is the name of the client method making the request, e.g.
+	// ContentItemUpdate.
+	Operation string
+
+	Method string
+
+	// Endpoint is the path of the request, or the absolute URL when following
+	// a link.
+	Endpoint string
+
+	// URL is the absolute URL the request is sent to.
+	URL string
+
+	// Body is the JSON encoded request body, if any.
+	Body []byte
+
+	// Header contains additional headers which are sent with the request.
+	Header http.Header
+
+	// Output is the value the response body is decoded into.
+	Output interface{}
+
+	// ReadOnly marks a request which does not modify data although its method
+	// is not GET or HEAD, e.g. a search which is sent as a POST.
+	ReadOnly bool
+}
+
+// modifiesData reports whether the request may modify data.
+func (r *Request) modifiesData() bool {
+	return !r.ReadOnly && r.Method != http.MethodGet && r.Method != http.MethodHead
+}
+
+// Response describes the response of the Amplience API to a Request.
+type Response struct {
+	// StatusCode is 0 when no response was received.
+	StatusCode int
+	Header     http.Header
+
+	// Attempts is the number of attempts made, including retries.
+	Attempts int
+}
+
+// Doer performs a Request. The returned error is an *ErrorResponse when the
+// API responded with an error status code. The Response may be nil when the
+// request was not sent at all.
+type Doer interface {
+	Do(ctx context.Context, req *Request) (*Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doer.
+type DoerFunc func(ctx context.Context, req *Request) (*Response, error)
+
+// Do calls f(ctx, req).
+func (f DoerFunc) Do(ctx context.Context, req *Request) (*Response, error) {
+	return f(ctx, req)
+}
+
+// Middleware wraps a Doer to add behaviour to every request made by the
+// Client, for example to add headers or to record metrics.
+type Middleware func(next Doer) Doer
+
+// ReadOnly is a Middleware which rejects all requests which would modify data
+// with ErrReadOnly.
+func ReadOnly(next Doer) Doer {
+	return DoerFunc(func(ctx context.Context, req *Request) (*Response, error) {
+		if req.modifiesData() {
+			return nil, ErrReadOnly
+		}
+		return next.Do(ctx, req)
+	})
+}
@@ -28,3 +28,32 @@ func createUpdatePatch(current interface{}, target interface{}) ([]byte, error)
 	}
 	return patch, nil
 }
+
+// addPatchVersion adds the version of the resource the patch is based on to a
+// merge patch.
+func addPatchVersion(patch []byte, version int) ([]byte, error) {
+	data := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &data); err != nil {
+		return nil, err
+	}
+	data["version"] = version
+	return json.Marshal(data)
+}
+
+// copyBody returns a deep copy of a content item body.
+func copyBody(body map[string]interface{}) (map[string]interface{}, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,181 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrPublishingFailed is returned when waiting for a publishing job which
+// failed or was cancelled.
+var ErrPublishingFailed = errors.New("publishing failed")
+
+// DefaultPublishingJobInterval is used by PublishingJobWait when the given
+// interval is not positive.
+const DefaultPublishingJobInterval = 2 * time.Second
+
+type PublishingJobState string
+
+const (
+	PublishingJobPreparing  PublishingJobState = "PREPARING"
+	PublishingJobPublishing PublishingJobState = "PUBLISHING"
+	PublishingJobCompleted  PublishingJobState = "COMPLETED"
+	PublishingJobFailed     PublishingJobState = "FAILED"
+	PublishingJobCancelled  PublishingJobState = "CANCELLED"
+)
+
+// PublishingJob is created when publishing or unpublishing a content item, and
+// tracks the progress of the (asynchronous) publish.
+type PublishingJob struct {
+	ID                 string             `json:"id"`
+	State              PublishingJobState `json:"state"`
+	PublishErrorStatus string             `json:"publishErrorStatus,omitempty"`
+	CreatedBy          string             `json:"createdBy"`
+	CreatedDate        *time.Time         `json:"createdDate"`
+	ScheduledDate      *time.Time         `json:"scheduledDate"`
+	Links              map[string]Link    `json:"_links"`
+}
+
+// Done reports whether the job is finished, either successfully or not.
+func (job PublishingJob) Done() bool {
+	switch job.State {
+	case PublishingJobCompleted, PublishingJobFailed, PublishingJobCancelled:
+		return true
+	}
+	return false
+}
+
+// PublishingStatus describes how the current version of a content item
+// relates to its published version.
+type PublishingStatus string
+
+const (
+	// PublishingStatusNone means the item was never published.
+	PublishingStatusNone PublishingStatus = "NONE"
+	// PublishingStatusEarly means an earlier version of the item is published.
+	PublishingStatusEarly PublishingStatus = "EARLY"
+	// PublishingStatusLatest means the current version of the item is
+	// published.
+	PublishingStatusLatest PublishingStatus = "LATEST"
+)
+
+// PublishingStatus returns whether the current Version of the item is
+// published.
+func (item ContentItem) PublishingStatus() PublishingStatus {
+	switch {
+	case item.LastPublishedVersion == 0:
+		return PublishingStatusNone
+	case item.Version > item.LastPublishedVersion:
+		return PublishingStatusEarly
+	default:
+		return PublishingStatusLatest
+	}
+}
+
+// HasUnpublishedChanges reports whether the current Version of the item is
+// ahead of the LastPublishedVersion.
+func (item ContentItem) HasUnpublishedChanges() bool {
+	return item.PublishingStatus() != PublishingStatusLatest
+}
+
+// ContentItemPublish publishes the current version of a content item. Publishing
+// happens asynchronously, use PublishingJobWait to wait until it is done.
+func (client *Client) ContentItemPublish(id string) (PublishingJob, error) {
+	return client.ContentItemPublishWithContext(context.Background(), id)
+}
+
+// ContentItemPublishWithContext is the same as ContentItemPublish with a custom context
+func (client *Client) ContentItemPublishWithContext(ctx context.Context, id string) (PublishingJob, error) {
+	endpoint := fmt.Sprintf("/content-items/%s/publish", id)
+	return client.startPublishingJob(ctx, "ContentItemPublish", endpoint)
+}
+
+// ContentItemUnpublish unpublishes a content item. Unpublishing happens
+// asynchronously, use PublishingJobWait to wait until it is done.
+func (client *Client) ContentItemUnpublish(id string) (PublishingJob, error) {
+	return client.ContentItemUnpublishWithContext(context.Background(), id)
+}
+
+// ContentItemUnpublishWithContext is the same as ContentItemUnpublish with a custom context
+func (client *Client) ContentItemUnpublishWithContext(ctx context.Context, id string) (PublishingJob, error) {
+	endpoint := fmt.Sprintf("/content-items/%s/unpublish", id)
+	return client.startPublishingJob(ctx, "ContentItemUnpublish", endpoint)
+}
+
+// startPublishingJob posts to a publish endpoint, which responds with the
+// location of the created publishing job.
+func (client *Client) startPublishingJob(ctx context.Context, operation string, endpoint string) (PublishingJob, error) {
+	result := PublishingJob{}
+	resp, err := client.requestResponse(ctx, operation, http.MethodPost, endpoint, nil, &result)
+	if err != nil || resp == nil || resp.Header == nil {
+		return result, err
+	}
+
+	if location := resp.Header.Get("Location"); location != "" && result.ID == "" {
+		result.ID = location[strings.LastIndex(location, "/")+1:]
+		result.Links = map[string]Link{"self": {Href: location}}
+	}
+	return result, nil
+}
+
+// PublishingJobGet returns the publishing job with the given id
+func (client *Client) PublishingJobGet(id string) (PublishingJob, error) {
+	return client.PublishingJobGetWithContext(context.Background(), id)
+}
+
+// PublishingJobGetWithContext is the same as PublishingJobGet with a custom context
+func (client *Client) PublishingJobGetWithContext(ctx context.Context, id string) (PublishingJob, error) {
+	endpoint := fmt.Sprintf("/publishing-jobs/%s", id)
+	result := PublishingJob{}
+
+	err := client.request(ctx, "PublishingJobGet", http.MethodGet, endpoint, nil, &result)
+	return result, err
+}
+
+// PublishingJobWait polls the publishing job with the given id every interval
+// until it is done, or every DefaultPublishingJobInterval when interval is not
+// positive. An error wrapping ErrPublishingFailed is returned when the job
+// failed or was cancelled.
+func (client *Client) PublishingJobWait(id string, interval time.Duration) (PublishingJob, error) {
+	return client.PublishingJobWaitWithContext(context.Background(), id, interval)
+}
+
+// PublishingJobWaitWithContext is the same as PublishingJobWait with a custom
+// context. Use a context with a deadline to limit how long to wait.
+func (client *Client) PublishingJobWaitWithContext(ctx context.Context, id string, interval time.Duration) (PublishingJob, error) {
+	if id == "" {
+		return PublishingJob{}, errors.New("publishing job id is required")
+	}
+
+	if interval <= 0 {
+		interval = DefaultPublishingJobInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := client.PublishingJobGetWithContext(ctx, id)
+		if err != nil {
+			return job, err
+		}
+
+		switch job.State {
+		case PublishingJobCompleted:
+			return job, nil
+		case PublishingJobFailed, PublishingJobCancelled:
+			if job.PublishErrorStatus != "" {
+				return job, fmt.Errorf("%w: job %s is %s (%s)", ErrPublishingFailed, job.ID, job.State, job.PublishErrorStatus)
+			}
+			return job, fmt.Errorf("%w: job %s is %s", ErrPublishingFailed, job.ID, job.State)
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
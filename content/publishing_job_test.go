@@ -0,0 +1,80 @@
+package content
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContentItemPublishLocation(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		switch r.URL.Path {
+		case "/content-items/item-id/publish":
+			w.Header().Set("Location", "https://api.amplience.net/v2/content/publishing-jobs/job-id")
+			w.WriteHeader(http.StatusAccepted)
+		case "/content-items/item-id/unpublish":
+			w.Write([]byte(`{"id": "other-job", "state": "PREPARING"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+	})
+
+	job, err := client.ContentItemPublish("item-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "job-id", job.ID)
+	assert.Equal(t, "https://api.amplience.net/v2/content/publishing-jobs/job-id", job.Links["self"].Href)
+
+	job, err = client.ContentItemUnpublish("item-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "other-job", job.ID)
+	assert.Equal(t, PublishingJobPreparing, job.State)
+}
+
+func TestPublishingJobWait(t *testing.T) {
+	var calls int32
+	states := []string{"PREPARING", "PUBLISHING", "COMPLETED"}
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/publishing-jobs/job-id", r.URL.Path)
+		state := states[atomic.AddInt32(&calls, 1)-1]
+		fmt.Fprintf(w, `{"id": "job-id", "state": %q}`, state)
+	})
+
+	job, err := client.PublishingJobWait("job-id", time.Millisecond)
+	assert.NoError(t, err)
+	assert.Equal(t, PublishingJobCompleted, job.State)
+	assert.True(t, job.Done())
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestPublishingJobWaitFailed(t *testing.T) {
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "job-id", "state": "FAILED", "publishErrorStatus": "INVALID_CONTENT"}`))
+	})
+
+	// A zero interval falls back to the default instead of panicking
+	job, err := client.PublishingJobWait("job-id", 0)
+	assert.ErrorIs(t, err, ErrPublishingFailed)
+	assert.Contains(t, err.Error(), "INVALID_CONTENT")
+	assert.Equal(t, PublishingJobFailed, job.State)
+}
+
+func TestPublishingJobWaitCancelled(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, ClientConfig{}, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{"id": "job-id", "state": "PUBLISHING"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	job, err := client.PublishingJobWaitWithContext(ctx, "job-id", time.Hour)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, PublishingJobPublishing, job.State)
+	assert.Equal(t, int32(1), calls)
+}
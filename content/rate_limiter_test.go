@@ -0,0 +1,53 @@
+package content
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterBurst(t *testing.T) {
+	limiter := NewRateLimiter(10, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	assert.Less(t, time.Since(start), 50*time.Millisecond, "the burst is allowed without waiting")
+
+	// The bucket is empty, so the next request waits for a token
+	start = time.Now()
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestRateLimiterDefaultBurst(t *testing.T) {
+	limiter := NewRateLimiter(10, 0)
+	assert.Equal(t, float64(1), limiter.burst)
+
+	assert.NoError(t, limiter.Wait(context.Background()))
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond)
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	limiter := NewRateLimiter(0.1, 1)
+	assert.NoError(t, limiter.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+
+	// The token reserved by the cancelled call is returned
+	assert.InDelta(t, 0, limiter.tokens, 0.01)
+}
+
+func TestRateLimiterNil(t *testing.T) {
+	var limiter *RateLimiter
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, limiter.Wait(ctx))
+}
@@ -0,0 +1,156 @@
+package content
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures if and how failed requests are retried by the Client.
+// A request is retried when the API responds with one of the
+// RetryableStatusCodes or when the request failed due to a network error.
+//
+// Only requests with one of the RetryableMethods, and requests which only read
+// data such as searches, are retried in all of these cases, since they are safe
+// to replay. Other requests (e.g. POST and PATCH) are
+// only retried on a 429 Too Many Requests response, because the API did not
+// process the request in that case.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between two attempts.
+	MaxBackoff time.Duration
+
+	// Multiplier is applied to the delay after every attempt. Defaults to 2.
+	Multiplier float64
+
+	// Jitter is the fraction (between 0 and 1) of the delay which is
+	// randomized, to prevent clients from retrying in lockstep.
+	Jitter float64
+
+	// RetryableStatusCodes are the response status codes which are retried.
+	RetryableStatusCodes []int
+
+	// RetryableMethods are the idempotent HTTP methods which are safe to
+	// replay.
+	RetryableMethods []string
+}
+
+// DefaultRetryPolicy returns a RetryPolicy which retries rate limited requests
+// and temporary server errors up to 4 times.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 5,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Multiplier:  2,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableMethods: []string{
+			http.MethodGet,
+			http.MethodHead,
+			http.MethodOptions,
+			http.MethodPut,
+			http.MethodDelete,
+		},
+	}
+}
+
+// maxAttempts returns the number of attempts a request may take. A nil policy
+// means a request is only tried once.
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// shouldRetry reports whether a request should be retried, given the response
+// or error of the last attempt.
+func (p *RetryPolicy) shouldRetry(req *Request, resp *http.Response, err error) bool {
+	if p == nil {
+		return false
+	}
+
+	replayable := req.ReadOnly
+	for _, m := range p.RetryableMethods {
+		if m == req.Method {
+			replayable = true
+			break
+		}
+	}
+
+	if err != nil {
+		return replayable
+	}
+
+	retryable := false
+	for _, code := range p.RetryableStatusCodes {
+		if code == resp.StatusCode {
+			retryable = true
+			break
+		}
+	}
+
+	// A 429 response means the request was not processed at all, so it is
+	// safe to replay regardless of the method.
+	return retryable && (replayable || resp.StatusCode == http.StatusTooManyRequests)
+}
+
+// delay returns how long to wait before the next attempt. The Retry-After
+// header of the response takes precedence over the backoff curve.
+func (p *RetryPolicy) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return d
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+
+	d := float64(p.MinBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		d = d - d*jitter + rand.Float64()*2*d*jitter
+	}
+	return time.Duration(d)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
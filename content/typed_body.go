@@ -0,0 +1,240 @@
+package content
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ContentMeta is the `_meta` block of a content item body.
+type ContentMeta struct {
+	Schema      string            `json:"schema,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	DeliveryKey string            `json:"deliveryKey,omitempty"`
+	Hierarchy   *ContentHierarchy `json:"hierarchy,omitempty"`
+
+	// Extra holds the fields of the `_meta` block which are not known to the
+	// SDK, so they are kept when the body is encoded again.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// ContentHierarchy describes the position of a content item in a hierarchy.
+type ContentHierarchy struct {
+	Root     bool   `json:"root"`
+	ParentID string `json:"parentId,omitempty"`
+}
+
+// UnmarshalJSON is a custom unmarshaller which keeps unknown fields in Extra
+func (m *ContentMeta) UnmarshalJSON(data []byte) error {
+	type meta ContentMeta
+	if err := json.Unmarshal(data, (*meta)(m)); err != nil {
+		return err
+	}
+
+	extra, err := extraFields(data, meta(*m))
+	if err != nil {
+		return err
+	}
+	m.Extra = extra
+	return nil
+}
+
+// MarshalJSON is a custom marshaller which adds the fields in Extra
+func (m ContentMeta) MarshalJSON() ([]byte, error) {
+	type meta ContentMeta
+	return marshalWithExtra(meta(m), m.Extra)
+}
+
+// TypedBody is a content item body decoded into a user defined type T, which
+// is typically a struct matching the content type schema.
+type TypedBody[T any] struct {
+	Meta    ContentMeta
+	Content T
+
+	// Extra holds the fields of the body which are not part of T, so they are
+	// kept when the body is encoded again.
+	Extra map[string]interface{}
+}
+
+// Map encodes the typed body into the representation used by
+// ContentItemInput.Body. T must encode to a JSON object.
+func (b TypedBody[T]) Map() (map[string]interface{}, error) {
+	data, err := marshalWithExtra(b.Content, b.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("content of %T does not encode to an object: %w", b.Content, err)
+	}
+
+	meta, err := json.Marshal(b.Meta)
+	if err != nil {
+		return nil, err
+	}
+	if string(meta) != "{}" {
+		result["_meta"] = json.RawMessage(meta)
+	}
+
+	// Decode again, so the result only contains plain JSON values like the
+	// bodies returned by the API.
+	if data, err = json.Marshal(result); err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(data, &body)
+	return body, err
+}
+
+// DecodeBody decodes the body of a content item into a TypedBody
+func DecodeBody[T any](item ContentItem) (TypedBody[T], error) {
+	result := TypedBody[T]{}
+
+	content := make(map[string]interface{}, len(item.Body))
+	for key, value := range item.Body {
+		if key == "_meta" {
+			continue
+		}
+		content[key] = value
+	}
+
+	data, err := json.Marshal(content)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(data, &result.Content); err != nil {
+		return result, err
+	}
+	if result.Extra, err = extraFields(data, result.Content); err != nil {
+		return result, err
+	}
+
+	if meta, ok := item.Body["_meta"]; ok {
+		data, err := json.Marshal(meta)
+		if err != nil {
+			return result, err
+		}
+		if err := json.Unmarshal(data, &result.Meta); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// TypedContentItem is a content item together with its decoded body
+type TypedContentItem[T any] struct {
+	ContentItem
+	TypedBody[T]
+}
+
+func newTypedContentItem[T any](item ContentItem) (TypedContentItem[T], error) {
+	body, err := DecodeBody[T](item)
+	return TypedContentItem[T]{ContentItem: item, TypedBody: body}, err
+}
+
+// ContentItemGetAs returns the content item with the given id, with its body
+// decoded into T.
+func ContentItemGetAs[T any](client *Client, id string) (TypedContentItem[T], error) {
+	return ContentItemGetAsWithContext[T](context.Background(), client, id)
+}
+
+// ContentItemGetAsWithContext is the same as ContentItemGetAs with a custom context
+func ContentItemGetAsWithContext[T any](ctx context.Context, client *Client, id string) (TypedContentItem[T], error) {
+	item, err := client.ContentItemGetWithContext(ctx, id)
+	if err != nil {
+		return TypedContentItem[T]{ContentItem: item}, err
+	}
+	return newTypedContentItem[T](item)
+}
+
+// ContentItemCreateTyped creates a new content item with the given typed body.
+// The Body of the input is ignored.
+func ContentItemCreateTyped[T any](client *Client, repositoryID string, input ContentItemInput, body TypedBody[T]) (TypedContentItem[T], error) {
+	return ContentItemCreateTypedWithContext(context.Background(), client, repositoryID, input, body)
+}
+
+// ContentItemCreateTypedWithContext is the same as ContentItemCreateTyped with a custom context
+func ContentItemCreateTypedWithContext[T any](ctx context.Context, client *Client, repositoryID string, input ContentItemInput, body TypedBody[T]) (TypedContentItem[T], error) {
+	var err error
+	if input.Body, err = body.Map(); err != nil {
+		return TypedContentItem[T]{}, err
+	}
+
+	item, err := client.ContentItemCreateWithContext(ctx, repositoryID, input)
+	if err != nil {
+		return TypedContentItem[T]{ContentItem: item}, err
+	}
+	return newTypedContentItem[T](item)
+}
+
+// ContentItemUpdateTyped updates a content item with the typed body of item.
+// See ContentItemUpdate for how conflicting updates are handled.
+func ContentItemUpdateTyped[T any](client *Client, item TypedContentItem[T]) (TypedContentItem[T], error) {
+	return ContentItemUpdateTypedWithContext(context.Background(), client, item)
+}
+
+// ContentItemUpdateTypedWithContext is the same as ContentItemUpdateTyped with a custom context
+func ContentItemUpdateTypedWithContext[T any](ctx context.Context, client *Client, item TypedContentItem[T]) (TypedContentItem[T], error) {
+	body, err := item.TypedBody.Map()
+	if err != nil {
+		return item, err
+	}
+
+	input := ContentItemInput{
+		Body:     body,
+		Label:    item.Label,
+		FolderID: item.FolderID,
+		Locale:   item.Locale,
+	}
+	result, err := client.ContentItemUpdateWithContext(ctx, item.ContentItem, input)
+	if err != nil {
+		return TypedContentItem[T]{ContentItem: result}, err
+	}
+	return newTypedContentItem[T](result)
+}
+
+// extraFields returns the fields of the JSON object in data which are lost when
+// it is decoded into value, by comparing it with the encoded value.
+func extraFields(data []byte, value interface{}) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	known := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &known); err != nil {
+		// The value is not an object, so none of the fields are kept
+		return fields, nil
+	}
+
+	for key := range known {
+		delete(fields, key)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// marshalWithExtra encodes value, which must encode to a JSON object, and adds
+// the extra fields which are not set by value itself.
+func marshalWithExtra(value interface{}, extra map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	fields := map[string]interface{}{}
+	for key, item := range extra {
+		fields[key] = item
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("%T does not encode to an object: %w", value, err)
+	}
+	return json.Marshal(fields)
+}
@@ -0,0 +1,44 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testBanner struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+func TestTypedBodyRoundTrip(t *testing.T) {
+	item := ContentItem{Body: map[string]interface{}{
+		"_meta": map[string]interface{}{
+			"schema":      "https://example.com/banner",
+			"deliveryKey": "home/banner",
+			"hierarchy":   map[string]interface{}{"root": false, "parentId": "parent-id"},
+			"custom":      "kept",
+		},
+		"title":    "Hello",
+		"subtitle": "kept as well",
+	}}
+
+	body, err := DecodeBody[testBanner](item)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", body.Content.Title)
+	assert.Equal(t, "https://example.com/banner", body.Meta.Schema)
+	assert.Equal(t, "home/banner", body.Meta.DeliveryKey)
+	assert.Equal(t, &ContentHierarchy{ParentID: "parent-id"}, body.Meta.Hierarchy)
+	assert.Equal(t, map[string]interface{}{"custom": "kept"}, body.Meta.Extra)
+	assert.Equal(t, map[string]interface{}{"subtitle": "kept as well"}, body.Extra)
+
+	result, err := body.Map()
+	assert.NoError(t, err)
+	assert.Equal(t, item.Body, result)
+
+	body.Content.Tags = []string{"new"}
+	result, err = body.Map()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"new"}, result["tags"])
+	assert.Equal(t, "kept as well", result["subtitle"])
+}
@@ -1,8 +1,10 @@
 package content
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -73,6 +75,32 @@ func (r *WebhookResults) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// Next returns the next page of results. ErrLinkNotFound is returned when
+// this is the last page.
+func (r *WebhookResults) Next(client *Client) (WebhookResults, error) {
+	return r.NextWithContext(context.Background(), client)
+}
+
+// NextWithContext is the same as Next with a custom context
+func (r *WebhookResults) NextWithContext(ctx context.Context, client *Client) (WebhookResults, error) {
+	result := WebhookResults{}
+	err := client.followRelation(ctx, "WebhookResults.Next", r.Links, "next", &result)
+	return result, err
+}
+
+// Prev returns the previous page of results. ErrLinkNotFound is returned when
+// this is the first page.
+func (r *WebhookResults) Prev(client *Client) (WebhookResults, error) {
+	return r.PrevWithContext(context.Background(), client)
+}
+
+// PrevWithContext is the same as Prev with a custom context
+func (r *WebhookResults) PrevWithContext(ctx context.Context, client *Client) (WebhookResults, error) {
+	result := WebhookResults{}
+	err := client.followRelation(ctx, "WebhookResults.Prev", r.Links, "prev", &result)
+	return result, err
+}
+
 type WebhookInput struct {
 	Label         string                `json:"label"`
 	Events        []string              `json:"events"`
@@ -118,6 +146,10 @@ type WebhookCustomPayload struct {
 }
 
 func (client *Client) WebhookCreate(hubID string, input WebhookInput) (Webhook, error) {
+	return client.WebhookCreateWithContext(context.Background(), hubID, input)
+}
+
+func (client *Client) WebhookCreateWithContext(ctx context.Context, hubID string, input WebhookInput) (Webhook, error) {
 	endpoint := fmt.Sprintf("/hubs/%s/webhooks", hubID)
 	result := Webhook{}
 
@@ -126,18 +158,26 @@ func (client *Client) WebhookCreate(hubID string, input WebhookInput) (Webhook,
 		return result, err
 	}
 
-	err = client.request(http.MethodPost, endpoint, body, &result)
+	err = client.request(ctx, "WebhookCreate", http.MethodPost, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) WebhookGet(hubID string, ID string) (Webhook, error) {
+	return client.WebhookGetWithContext(context.Background(), hubID, ID)
+}
+
+func (client *Client) WebhookGetWithContext(ctx context.Context, hubID string, ID string) (Webhook, error) {
 	endpoint := fmt.Sprintf("/hubs/%s/webhooks/%s", hubID, ID)
 	result := Webhook{}
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "WebhookGet", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) WebhookUpdate(hubID string, current Webhook, input WebhookInput) (Webhook, error) {
+	return client.WebhookUpdateWithContext(context.Background(), hubID, current, input)
+}
+
+func (client *Client) WebhookUpdateWithContext(ctx context.Context, hubID string, current Webhook, input WebhookInput) (Webhook, error) {
 	result := Webhook{}
 
 	body, err := createUpdatePatch(
@@ -162,39 +202,52 @@ func (client *Client) WebhookUpdate(hubID string, current Webhook, input Webhook
 	}
 
 	endpoint := fmt.Sprintf("/hubs/%s/webhooks/%s", hubID, current.ID)
-	err = client.request(http.MethodPatch, endpoint, body, &result)
+	err = client.request(ctx, "WebhookUpdate", http.MethodPatch, endpoint, body, &result)
 	return result, err
 }
 
 func (client *Client) WebhookDelete(hub_id string, id string) error {
+	return client.WebhookDeleteWithContext(context.Background(), hub_id, id)
+}
+
+func (client *Client) WebhookDeleteWithContext(ctx context.Context, hub_id string, id string) error {
 	endpoint := fmt.Sprintf("/hubs/%s/webhooks/%s", hub_id, id)
-	err := client.request(http.MethodDelete, endpoint, nil, nil)
+	err := client.request(ctx, "WebhookDelete", http.MethodDelete, endpoint, nil, nil)
 	return err
 }
 
 func (client *Client) WebhookList(hub_id string, parameters PaginationParameters) (WebhookResults, error) {
+	return client.WebhookListWithContext(context.Background(), hub_id, parameters)
+}
+
+func (client *Client) WebhookListWithContext(ctx context.Context, hub_id string, parameters PaginationParameters) (WebhookResults, error) {
 	result := WebhookResults{}
 	endpoint := fmt.Sprintf("/hubs/%s/webhooks?%s", hub_id, PaginationQueryString(parameters))
-	err := client.request(http.MethodGet, endpoint, nil, &result)
+	err := client.request(ctx, "WebhookList", http.MethodGet, endpoint, nil, &result)
 	return result, err
 }
 
 func (client *Client) WebhookGetAll(hubID string) ([]Webhook, error) {
-	parameters := PaginationParameters{}
-
-	response, err := client.WebhookList(hubID, parameters)
+	return client.WebhookGetAllWithContext(context.Background(), hubID)
+}
 
-	var result []Webhook
-	result = append(result, response.Items...)
+func (client *Client) WebhookGetAllWithContext(ctx context.Context, hubID string) ([]Webhook, error) {
+	parameters := PaginationParameters{}
+	return Collect(client.WebhookIterateWithContext(ctx, hubID, parameters))
+}
 
-	for parameters.Page < response.Page.TotalPages-1 {
-		parameters.Page++
-		response, err := client.WebhookList(hubID, parameters)
-		if err != nil {
-			break
-		}
-		result = append(result, response.Items...)
-	}
+// WebhookIterate returns an iterator over all webhooks within the given hub.
+// Pages are fetched as needed, so iteration can be stopped early. The Page
+// parameter is ignored.
+func (client *Client) WebhookIterate(hubID string, parameters PaginationParameters) iter.Seq2[Webhook, error] {
+	return client.WebhookIterateWithContext(context.Background(), hubID, parameters)
+}
 
-	return result, err
+// WebhookIterateWithContext is the same as WebhookIterate with a custom context
+func (client *Client) WebhookIterateWithContext(ctx context.Context, hubID string, parameters PaginationParameters) iter.Seq2[Webhook, error] {
+	return Iterate(func(page int) ([]Webhook, PageInformation, error) {
+		parameters.Page = page
+		response, err := client.WebhookListWithContext(ctx, hubID, parameters)
+		return response.Items, response.Page, err
+	})
 }
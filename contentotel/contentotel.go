@@ -0,0 +1,155 @@
+// Package contentotel provides OpenTelemetry instrumentation for the Amplience
+// content client. It is a separate module, so the OpenTelemetry dependencies
+// are only pulled in when the instrumentation is used.
+//
+// The instrumentation is added to a client as middleware:
+//
+//	client, err := content.NewClient(&content.ClientConfig{
+//		ClientID:     "<my-client-id>",
+//		ClientSecret: "<my-client-secret>",
+//		Middlewares:  []content.Middleware{contentotel.Middleware()},
+//	})
+package contentotel
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/labd/amplience-go-sdk/content"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/labd/amplience-go-sdk/contentotel"
+
+// resourceAttributes maps the collections in an API path to the attribute
+// which records the id following it.
+var resourceAttributes = map[string]attribute.Key{
+	"hubs":                 "amplience.hub.id",
+	"algolia-search":       "amplience.hub.id",
+	"content-repositories": "amplience.content_repository.id",
+	"content-items":        "amplience.content_item.id",
+	"content-types":        "amplience.content_type.id",
+	"content-type-schemas": "amplience.content_type_schema.id",
+	"folders":              "amplience.folder.id",
+	"webhooks":             "amplience.webhook.id",
+	"extensions":           "amplience.extension.id",
+}
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// Option configures the instrumentation.
+type Option func(*config)
+
+// WithTracerProvider sets the TracerProvider used to create spans. Defaults to
+// the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider sets the MeterProvider used to record metrics. Defaults to
+// the global MeterProvider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// Middleware returns a content.Middleware which creates a span for every API
+// call, named after the client method (e.g. ContentItemUpdate), and records
+// the number of requests, errors and their duration.
+func Middleware(opts ...Option) content.Middleware {
+	cfg := config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	tracer := cfg.tracerProvider.Tracer(instrumentationName)
+	meter := cfg.meterProvider.Meter(instrumentationName)
+
+	// Creating instruments only fails for invalid names, in which case the
+	// returned no-op instruments are fine to use.
+	requests, _ := meter.Int64Counter("amplience.client.requests",
+		metric.WithDescription("Number of requests made to the Amplience API"))
+	failures, _ := meter.Int64Counter("amplience.client.errors",
+		metric.WithDescription("Number of requests to the Amplience API which failed"))
+	duration, _ := meter.Float64Histogram("amplience.client.duration",
+		metric.WithDescription("Duration of requests to the Amplience API, including retries"),
+		metric.WithUnit("s"))
+
+	return func(next content.Doer) content.Doer {
+		return content.DoerFunc(func(ctx context.Context, req *content.Request) (*content.Response, error) {
+			name := req.Operation
+			if name == "" {
+				name = req.Method
+			}
+
+			attrs := []attribute.KeyValue{
+				attribute.String("amplience.operation", name),
+				attribute.String("http.request.method", req.Method),
+			}
+
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(attrs...),
+				trace.WithAttributes(attribute.String("url.full", req.URL)),
+				trace.WithAttributes(resourceIDs(req.URL)...))
+			defer span.End()
+
+			start := time.Now()
+			resp, err := next.Do(ctx, req)
+			elapsed := time.Since(start).Seconds()
+
+			if resp != nil {
+				if resp.StatusCode > 0 {
+					attrs = append(attrs, attribute.Int("http.response.status_code", resp.StatusCode))
+				}
+				if resp.Attempts > 1 {
+					span.SetAttributes(attribute.Int("amplience.retry.count", resp.Attempts-1))
+				}
+			}
+			span.SetAttributes(attrs...)
+
+			requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+			duration.Record(ctx, elapsed, metric.WithAttributes(attrs...))
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				failures.Add(ctx, 1, metric.WithAttributes(attrs...))
+			}
+			return resp, err
+		})
+	}
+}
+
+// resourceIDs returns the ids of the resources in the path of the URL as span
+// attributes, e.g. the hub id for `/hubs/{id}/webhooks`.
+func resourceIDs(rawURL string) []attribute.KeyValue {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil
+	}
+
+	var result []attribute.KeyValue
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if key, ok := resourceAttributes[segments[i]]; ok {
+			result = append(result, key.String(segments[i+1]))
+			i++
+		}
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package contentotel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labd/amplience-go-sdk/content"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMiddleware(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			w.Header().Set("content-type", "application/json")
+			w.Write([]byte(`{"access_token": "token", "token_type": "bearer", "expires_in": 3600}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	client, err := content.NewClient(&content.ClientConfig{
+		URL:     server.URL,
+		AuthURL: server.URL + "/oauth/token",
+		Middlewares: []content.Middleware{Middleware(
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		)},
+	})
+	assert.NoError(t, err)
+
+	_, err = client.ContentItemGet("item-id")
+	assert.True(t, content.IsNotFound(err))
+
+	ended := spans.Ended()
+	assert.Len(t, ended, 1)
+	assert.Equal(t, "ContentItemGet", ended[0].Name())
+	assert.Equal(t, codes.Error, ended[0].Status().Code)
+	assert.Contains(t, ended[0].Attributes(), attribute.String("amplience.content_item.id", "item-id"))
+	assert.Contains(t, ended[0].Attributes(), attribute.Int("http.response.status_code", http.StatusNotFound))
+
+	var metrics metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &metrics))
+	names := []string{}
+	for _, m := range metrics.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	assert.ElementsMatch(t, []string{"amplience.client.requests", "amplience.client.errors", "amplience.client.duration"}, names)
+}
+
+func TestResourceIDs(t *testing.T) {
+	assert.Equal(t,
+		[]attribute.KeyValue{
+			attribute.String("amplience.hub.id", "hub"),
+			attribute.String("amplience.webhook.id", "webhook"),
+		},
+		resourceIDs("https://api.amplience.net/v2/content/hubs/hub/webhooks/webhook"))
+}
@@ -1,11 +1,21 @@
 module github.com/labd/amplience-go-sdk
 
-go 1.15
+go 1.23
 
 require (
 	github.com/evanphx/json-patch v0.5.2
 	github.com/mitchellh/mapstructure v1.5.0
 	github.com/stretchr/testify v1.8.1
-	golang.org/x/net v0.17.0 // indirect
 	golang.org/x/oauth2 v0.11.0
 )
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/net v0.17.0 // indirect
+	google.golang.org/appengine v1.6.7 // indirect
+	google.golang.org/protobuf v1.31.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,423 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const contentPackage = "github.com/labd/amplience-go-sdk/content"
+
+// GeneratorConfig configures the generation of Go types
+type GeneratorConfig struct {
+	// Package is the name of the generated package
+	Package string
+
+	// TypeNames overrides the name of the type generated for a schema, by
+	// schema id. By default the name is derived from the title of the schema.
+	TypeNames map[string]string
+
+	// OmitMeta leaves out the Meta field for the `_meta` of content. Use it
+	// when the types are used with content.DecodeBody, which returns the
+	// `_meta` separately as TypedBody.Meta.
+	OmitMeta bool
+}
+
+// Generate generates Go types for the given schemas, which can be used with
+// encoding/json or, with OmitMeta, with content.DecodeBody. Content types get a
+// Meta field for their `_meta`. A struct is generated for every schema with properties
+// and for every entry in `definitions`. Types are shared between schemas, so
+// `$ref`s to other schemas in the set result in the type of that schema.
+// Required properties which refer back to their own struct are pointers.
+func Generate(config GeneratorConfig, schemas ...*Schema) ([]byte, error) {
+	if config.Package == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	g := &generator{
+		config:  config,
+		names:   map[string]string{},
+		used:    map[string]bool{},
+		structs: map[string]bool{},
+		schemas: map[string]*Schema{},
+	}
+
+	// Name all schemas and definitions first, so they can refer to each other
+	for _, s := range schemas {
+		g.schemas[normalizeID(s.ID)] = s
+		name := g.uniqueName(g.schemaName(s))
+		g.names[s.ID] = name
+		g.structs[name] = len(s.Properties) > 0 || s.IsContent()
+		for _, def := range sortedKeys(s.Definitions) {
+			defName := g.uniqueName(name + exportName(def))
+			g.names[s.ID+"#/definitions/"+def] = defName
+			g.structs[defName] = len(s.Definitions[def].Properties) > 0
+		}
+	}
+
+	for _, s := range schemas {
+		name := g.names[s.ID]
+		if len(s.Properties) > 0 || s.IsContent() {
+			g.declareStruct(s, name, s, s.ID)
+		}
+		for _, def := range sortedKeys(s.Definitions) {
+			g.declare(s, g.names[s.ID+"#/definitions/"+def], s.Definitions[def], fmt.Sprintf("the definition %s of %s", def, s.ID))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by amplience-codegen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", config.Package)
+	if strings.Contains(strings.Join(g.decls, ""), "content.") {
+		fmt.Fprintf(buf, "import %q\n\n", contentPackage)
+	}
+	for _, decl := range g.decls {
+		buf.WriteString(decl)
+		buf.WriteString("\n")
+	}
+
+	result, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return result, nil
+}
+
+type generator struct {
+	config  GeneratorConfig
+	names   map[string]string
+	used    map[string]bool
+	structs map[string]bool
+	schemas map[string]*Schema
+	decls   []string
+}
+
+func (g *generator) schemaName(s *Schema) string {
+	if name, ok := g.config.TypeNames[s.ID]; ok {
+		return name
+	}
+	if s.Title != "" {
+		return exportName(s.Title)
+	}
+	id := strings.TrimSuffix(s.ID, ".json")
+	return exportName(path.Base(id))
+}
+
+func (g *generator) uniqueName(name string) string {
+	result := name
+	for i := 2; g.used[result]; i++ {
+		result = name + strconv.Itoa(i)
+	}
+	g.used[result] = true
+	return result
+}
+
+// reserve adds an empty declaration which is filled in later, so types are
+// declared before the nested types they use.
+func (g *generator) reserve() int {
+	g.decls = append(g.decls, "")
+	return len(g.decls) - 1
+}
+
+// declare declares a named type for the schema
+func (g *generator) declare(root *Schema, name string, s *Schema, source string) {
+	switch {
+	case len(s.Properties) > 0:
+		g.declareStruct(root, name, s, source)
+	case isStringEnum(s):
+		g.declareEnum(name, s, source)
+	default:
+		index := g.reserve()
+		buf := &bytes.Buffer{}
+		writeComment(buf, name, s, source)
+		fmt.Fprintf(buf, "type %s %s\n", name, g.goType(root, name+"Item", s, "the items of "+name))
+		g.decls[index] = buf.String()
+	}
+}
+
+func (g *generator) declareStruct(root *Schema, name string, s *Schema, source string) {
+	index := g.reserve()
+
+	buf := &bytes.Buffer{}
+	writeComment(buf, name, s, source)
+	fmt.Fprintf(buf, "type %s struct {\n", name)
+	g.structs[name] = true
+	includeMeta := s.IsContent() && !g.config.OmitMeta
+	if includeMeta {
+		buf.WriteString("Meta content.ContentMeta `json:\"_meta\"`\n")
+	}
+
+	fields := map[string]bool{"Meta": includeMeta}
+	for _, property := range s.OrderedProperties() {
+		if property == "_meta" {
+			continue
+		}
+		ps := s.Properties[property]
+		required := s.IsRequired(property)
+
+		field := exportName(property)
+		for i := 2; fields[field]; i++ {
+			field = exportName(property) + strconv.Itoa(i)
+		}
+		fields[field] = true
+
+		typ := g.goType(root, name+field, ps, fmt.Sprintf("the property %s of %s", property, name))
+		if g.isStruct(typ) && (!required || g.containsByValue(root, ps, s, map[*Schema]bool{})) {
+			typ = "*" + typ
+		}
+
+		tag := property
+		if !required {
+			tag += ",omitempty"
+		}
+
+		if doc := description(ps); doc != "" {
+			writeLines(buf, doc)
+		}
+		fmt.Fprintf(buf, "%s %s `json:%q`\n", field, typ, tag)
+	}
+	buf.WriteString("}\n")
+	g.decls[index] = buf.String()
+}
+
+func (g *generator) declareEnum(name string, s *Schema, source string) {
+	index := g.reserve()
+
+	buf := &bytes.Buffer{}
+	writeComment(buf, name, s, source)
+	fmt.Fprintf(buf, "type %s string\n\nconst (\n", name)
+	seen := map[string]bool{}
+	for _, value := range s.Enum {
+		constant := name + exportName(value.(string))
+		for i := 2; seen[constant]; i++ {
+			constant = name + exportName(value.(string)) + strconv.Itoa(i)
+		}
+		seen[constant] = true
+		fmt.Fprintf(buf, "%s %s = %q\n", constant, name, value)
+	}
+	buf.WriteString(")\n")
+	g.decls[index] = buf.String()
+}
+
+// goType returns the Go type for a schema, declaring new types named after
+// hint for nested objects and enums.
+func (g *generator) goType(root *Schema, hint string, s *Schema, source string) string {
+	if ref := s.TypeRef(); ref != "" {
+		return g.refType(root, hint, s, ref, source)
+	}
+
+	switch {
+	case isStringEnum(s):
+		name := g.uniqueName(hint)
+		g.declareEnum(name, s, source)
+		return name
+	case len(s.Type) != 1:
+		return "interface{}"
+	}
+
+	switch s.Type[0] {
+	case "string":
+		return "string"
+	case "integer":
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(root, hint+"Item", s.Items, "the items of "+source)
+	case "object":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}"
+		}
+		name := g.uniqueName(hint)
+		g.declareStruct(root, name, s, source)
+		return name
+	}
+	return "interface{}"
+}
+
+// refType returns the Go type for a `$ref`
+func (g *generator) refType(root *Schema, hint string, s *Schema, ref string, source string) string {
+	if def, ok := CoreDefinition(ref); ok {
+		switch def {
+		case "content-link":
+			return "content.ContentLink"
+		case "content-reference":
+			return "content.ContentReference"
+		case "image-link", "video-link":
+			return "content.MediaLink"
+		case "localized-string":
+			return "content.LocalizedValue[string]"
+		case "localized-number":
+			return "content.LocalizedValue[float64]"
+		case "localized-integer":
+			return "content.LocalizedValue[int]"
+		case "localized-boolean":
+			return "content.LocalizedValue[bool]"
+		case "localized-image", "localized-video":
+			return "content.LocalizedValue[content.MediaLink]"
+		case "localized-value":
+			return "content.LocalizedValue[" + g.localizedValueType(root, hint, s, source) + "]"
+		}
+		return "interface{}"
+	}
+
+	if strings.HasPrefix(ref, "#") {
+		ref = root.ID + ref
+	}
+	if name, ok := g.names[ref]; ok {
+		return name
+	}
+	return "interface{}"
+}
+
+// localizedValueType returns the type of the values of a localized value,
+// which is defined with an `allOf` next to the `$ref`:
+//
+//	{"properties": {"values": {"items": {"properties": {"value": {...}}}}}}
+func (g *generator) localizedValueType(root *Schema, hint string, s *Schema, source string) string {
+	for _, sub := range s.AllOf {
+		values, ok := sub.Properties["values"]
+		if !ok || values.Items == nil {
+			continue
+		}
+		if value, ok := values.Items.Properties["value"]; ok {
+			return g.goType(root, hint+"Value", value, "the localized values of "+source)
+		}
+	}
+	return "interface{}"
+}
+
+// containsByValue reports whether a value of schema s contains a value of goal
+// through required properties, which are not pointers. A struct which
+// contains itself that way can not be declared in Go.
+func (g *generator) containsByValue(root *Schema, s *Schema, goal *Schema, visited map[*Schema]bool) bool {
+	if ref := s.TypeRef(); ref != "" {
+		if _, ok := CoreDefinition(ref); ok {
+			return false
+		}
+		refRoot, target, err := resolveRef(root, ref, func(id string) (*Schema, bool) {
+			result, ok := g.schemas[normalizeID(id)]
+			return result, ok
+		})
+		if err != nil {
+			return false
+		}
+		root, s = refRoot, target
+	}
+
+	if s == goal {
+		return true
+	}
+	if visited[s] {
+		return false
+	}
+	visited[s] = true
+
+	for _, name := range sortedKeys(s.Properties) {
+		if s.IsRequired(name) && g.containsByValue(root, s.Properties[name], goal, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// isStruct reports whether the Go type is a struct, which is used as a pointer
+// when it is optional.
+func (g *generator) isStruct(typ string) bool {
+	return strings.HasPrefix(typ, "content.") || g.structs[typ]
+}
+
+func isStringEnum(s *Schema) bool {
+	if len(s.Enum) == 0 {
+		return false
+	}
+	for _, value := range s.Enum {
+		if _, ok := value.(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func description(s *Schema) string {
+	switch {
+	case s.Title != "" && s.Description != "":
+		return s.Title + ": " + s.Description
+	case s.Description != "":
+		return s.Description
+	default:
+		return s.Title
+	}
+}
+
+func writeComment(buf *bytes.Buffer, name string, s *Schema, source string) {
+	writeLines(buf, fmt.Sprintf("%s is generated from %s", name, source))
+	if doc := description(s); doc != "" {
+		buf.WriteString("//\n")
+		writeLines(buf, doc)
+	}
+}
+
+func writeLines(buf *bytes.Buffer, doc string) {
+	for _, line := range strings.Split(doc, "\n") {
+		fmt.Fprintf(buf, "// %s\n", strings.TrimSpace(line))
+	}
+}
+
+// commonInitialisms are written in upper case in Go names
+var commonInitialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "ID": true, "JSON": true,
+	"SEO": true, "SKU": true, "URI": true, "URL": true,
+}
+
+// exportName converts a JSON name such as `hero-banner` or `imageUrl` into an
+// exported Go name, `HeroBanner` and `ImageURL`.
+func exportName(name string) string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, string(word))
+			}
+			word = nil
+			continue
+		case unicode.IsUpper(r) && len(word) > 0 && (unicode.IsLower(word[len(word)-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])):
+			words = append(words, string(word))
+			word = nil
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+
+	result := &strings.Builder{}
+	for _, w := range words {
+		if upper := strings.ToUpper(w); commonInitialisms[upper] {
+			result.WriteString(upper)
+			continue
+		}
+		first := []rune(w)
+		result.WriteString(strings.ToUpper(string(first[0])) + string(first[1:]))
+	}
+
+	if result.Len() == 0 {
+		return "Value"
+	}
+	if s := result.String(); unicode.IsDigit([]rune(s)[0]) {
+		return "X" + s
+	}
+	return result.String()
+}
@@ -0,0 +1,77 @@
+package schema
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestSchema(t *testing.T, name string) *Schema {
+	data, err := os.ReadFile("testdata/" + name)
+	assert.NoError(t, err)
+	result, err := Parse(data)
+	assert.NoError(t, err)
+	return result
+}
+
+func TestGenerate(t *testing.T) {
+	banner := loadTestSchema(t, "banner.json")
+
+	code, err := Generate(GeneratorConfig{Package: "models"}, banner)
+	assert.NoError(t, err)
+
+	source := string(code)
+	assert.Contains(t, source, `import "github.com/labd/amplience-go-sdk/content"`)
+	assert.Contains(t, source, "type Banner struct {")
+	assert.Regexp(t, `Meta\s+content.ContentMeta\s+`+"`json:\"_meta\"`", source)
+	assert.Regexp(t, `Headline\s+string\s+`+"`json:\"headline\"`", source)
+	assert.Regexp(t, `Title\s+\*content.LocalizedValue\[string\]`, source)
+	assert.Regexp(t, `Theme\s+BannerTheme\s+`+"`json:\"theme,omitempty\"`", source)
+	assert.Contains(t, source, `BannerThemeDark  BannerTheme = "dark"`)
+	assert.Regexp(t, `Image\s+\*content.MediaLink`, source)
+	assert.Regexp(t, `Cta\s+\*BannerLink`, source)
+	assert.Regexp(t, `Related\s+\[\]content.ContentLink`, source)
+	assert.Contains(t, source, "type BannerLink struct {")
+	assert.Regexp(t, `URL\s+string\s+`+"`json:\"url\"`", source)
+
+	code, err = Generate(GeneratorConfig{Package: "models", OmitMeta: true}, banner)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(code), "_meta")
+}
+
+func TestGenerateRecursive(t *testing.T) {
+	nav := mustParse(t, `{
+		"$id": "https://example.com/nav.json",
+		"title": "Nav",
+		"definitions": {
+			"node": {
+				"type": "object",
+				"properties": {
+					"label": {"type": "string"},
+					"next": {"$ref": "#/definitions/node"},
+					"pair": {"$ref": "#/definitions/pair"},
+					"children": {"type": "array", "items": {"$ref": "#/definitions/node"}}
+				},
+				"required": ["label", "next", "pair", "children"]
+			},
+			"pair": {
+				"type": "object",
+				"properties": {"node": {"$ref": "#/definitions/node"}},
+				"required": ["node"]
+			}
+		},
+		"properties": {"root": {"$ref": "#/definitions/node"}},
+		"required": ["root"]
+	}`)
+
+	code, err := Generate(GeneratorConfig{Package: "models"}, nav)
+	assert.NoError(t, err)
+
+	source := string(code)
+	assert.Regexp(t, `Root\s+NavNode\s+`, source)
+	assert.Regexp(t, `Next\s+\*NavNode\s+`, source)
+	assert.Regexp(t, `Pair\s+\*NavPair\s+`, source)
+	assert.Regexp(t, `Children\s+\[\]NavNode\s+`, source)
+	assert.Regexp(t, `Node\s+\*NavNode\s+`, source)
+}
@@ -0,0 +1,342 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/labd/amplience-go-sdk/content"
+)
+
+// ChangeKind classifies a change between two versions of a schema
+type ChangeKind string
+
+const (
+	ChangePropertyAdded          ChangeKind = "PROPERTY_ADDED"
+	ChangePropertyRemoved        ChangeKind = "PROPERTY_REMOVED"
+	ChangeRequiredAdded          ChangeKind = "REQUIRED_ADDED"
+	ChangeRequiredRemoved        ChangeKind = "REQUIRED_REMOVED"
+	ChangeEnumNarrowed           ChangeKind = "ENUM_NARROWED"
+	ChangeEnumWidened            ChangeKind = "ENUM_WIDENED"
+	ChangeTypeChanged            ChangeKind = "TYPE_CHANGED"
+	ChangeConstraintTightened    ChangeKind = "CONSTRAINT_TIGHTENED"
+	ChangeConstraintLoosened     ChangeKind = "CONSTRAINT_LOOSENED"
+	ChangeValidationLevelChanged ChangeKind = "VALIDATION_LEVEL_CHANGED"
+)
+
+// Change is a semantic change between two versions of a schema. Breaking
+// changes can make existing content invalid.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	// Path is the JSON pointer of the changed property within the content,
+	// where `*` stands for all items of an array.
+	Path     string `json:"path"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+}
+
+func (c Change) String() string {
+	if c.Path == "" {
+		return c.Message
+	}
+	return fmt.Sprintf("%s: %s", c.Path, c.Message)
+}
+
+// HasBreakingChanges reports whether any of the changes is breaking
+func HasBreakingChanges(changes []Change) bool {
+	for _, change := range changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the semantic changes between two versions of a schema. `$ref`s
+// within a schema are followed, `$ref`s to other schemas are compared by their
+// value.
+func Diff(from *Schema, to *Schema) []Change {
+	d := &differ{fromRoot: from, toRoot: to}
+	d.compare("", from, to, 0)
+	return d.changes
+}
+
+// CompareContentTypeSchemas returns the semantic changes between two content
+// type schemas, including a change of their ValidationLevel. Use
+// content.DiffContentTypeSchemas for a JSON Patch of their bodies instead.
+func CompareContentTypeSchemas(from content.ContentTypeSchema, to content.ContentTypeSchema) ([]Change, error) {
+	src, err := ParseContentTypeSchema(from)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := ParseContentTypeSchema(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Change
+	if from.ValidationLevel != to.ValidationLevel {
+		result = append(result, Change{
+			Kind:     ChangeValidationLevelChanged,
+			Breaking: true,
+			Message:  fmt.Sprintf("validation level changed from %s to %s", from.ValidationLevel, to.ValidationLevel),
+		})
+	}
+	return append(result, Diff(src, dst)...), nil
+}
+
+// Impact is a content item which is not valid for a new version of its schema
+type Impact struct {
+	Item   content.ContentItem   `json:"item"`
+	Errors []content.ErrorObject `json:"errors"`
+}
+
+// ScanImpact validates all active content items of the hub which use the
+// schema against the new version of it, and returns the items which would
+// become invalid. Since all content repositories of the hub are listed this
+// can be slow for large hubs.
+func ScanImpact(client *content.Client, hubID string, to *Schema, opts ...ValidateOption) ([]Impact, error) {
+	return ScanImpactWithContext(context.Background(), client, hubID, to, opts...)
+}
+
+// ScanImpactWithContext is the same as ScanImpact with a custom context
+func ScanImpactWithContext(ctx context.Context, client *content.Client, hubID string, to *Schema, opts ...ValidateOption) ([]Impact, error) {
+	var result []Impact
+	for repository, err := range client.ContentRepositoryIterateWithContext(ctx, hubID, content.PaginationParameters{}) {
+		if err != nil {
+			return nil, err
+		}
+
+		parameters := content.ContentItemPaginationParameters{Status: content.StatusActive}
+		for item, err := range client.ContentItemIterateWithContext(ctx, repository.ID, parameters) {
+			if err != nil {
+				return nil, err
+			}
+
+			meta, _ := item.Body["_meta"].(map[string]interface{})
+			if schemaID, _ := meta["schema"].(string); normalizeID(schemaID) != normalizeID(to.ID) {
+				continue
+			}
+			if errs := Validate(to, item.Body, opts...); len(errs) > 0 {
+				result = append(result, Impact{Item: item, Errors: errs})
+			}
+		}
+	}
+	return result, nil
+}
+
+type differ struct {
+	fromRoot *Schema
+	toRoot   *Schema
+	changes  []Change
+}
+
+func (d *differ) add(kind ChangeKind, path string, breaking bool, format string, args ...interface{}) {
+	d.changes = append(d.changes, Change{
+		Kind:     kind,
+		Path:     path,
+		Breaking: breaking,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// resolveLocal follows `$ref`s within the same schema
+func resolveLocal(root *Schema, s *Schema) *Schema {
+	for i := 0; i < maxDepth && s != nil && strings.HasPrefix(s.Ref, "#"); i++ {
+		target, ok := root.Lookup(strings.TrimPrefix(s.Ref, "#"))
+		if !ok {
+			break
+		}
+		s = target
+	}
+	return s
+}
+
+// resolveTypeRef returns the TypeRef of a schema after following `$ref`s
+// within the same schema, so moving a type to a local definition is not a
+// change while replacing a `$ref` to another schema by a local one is.
+func resolveTypeRef(root *Schema, s *Schema) string {
+	ref := s.TypeRef()
+	for i := 0; i < maxDepth && strings.HasPrefix(ref, "#"); i++ {
+		target, ok := root.Lookup(strings.TrimPrefix(ref, "#"))
+		if !ok {
+			break
+		}
+		ref = target.TypeRef()
+	}
+	return ref
+}
+
+func (d *differ) compare(path string, from *Schema, to *Schema, depth int) {
+	if depth > maxDepth {
+		return
+	}
+	from = resolveLocal(d.fromRoot, from)
+	to = resolveLocal(d.toRoot, to)
+
+	if resolveTypeRef(d.fromRoot, from) != resolveTypeRef(d.toRoot, to) {
+		d.add(ChangeTypeChanged, path, true, "type changed from %s to %s", describeType(from), describeType(to))
+		return
+	}
+	if !reflect.DeepEqual(from.Type, to.Type) {
+		// Adding a type to an untyped schema narrows it as well
+		widened := len(to.Type) == 0 || len(from.Type) > 0 && allTypesAllowed(from.Type, to.Type)
+		d.add(ChangeTypeChanged, path, !widened, "type changed from %s to %s", describeType(from), describeType(to))
+		if !widened {
+			return
+		}
+	}
+
+	d.compareEnum(path, from, to)
+	d.compareConstraints(path, from, to)
+
+	for i := 0; i < len(from.AllOf) && i < len(to.AllOf); i++ {
+		d.compare(path, from.AllOf[i], to.AllOf[i], depth+1)
+	}
+	if from.Items != nil && to.Items != nil {
+		d.compare(path+"/*", from.Items, to.Items, depth+1)
+	}
+
+	d.compareProperties(path, from, to, depth)
+}
+
+func (d *differ) compareProperties(path string, from *Schema, to *Schema, depth int) {
+	for _, name := range sortedKeys(from.Properties) {
+		if _, ok := to.Properties[name]; !ok {
+			d.add(ChangePropertyRemoved, path+"/"+escapePointer(name), true, "property removed")
+		}
+	}
+
+	for _, name := range to.OrderedProperties() {
+		child := path + "/" + escapePointer(name)
+		ps, existed := from.Properties[name]
+		required := to.IsRequired(name)
+
+		switch {
+		case !existed && required:
+			d.add(ChangeRequiredAdded, child, true, "required property added")
+		case !existed:
+			d.add(ChangePropertyAdded, child, false, "optional property added")
+		default:
+			if wasRequired := from.IsRequired(name); required && !wasRequired {
+				d.add(ChangeRequiredAdded, child, true, "property is now required")
+			} else if !required && wasRequired {
+				d.add(ChangeRequiredRemoved, child, false, "property is no longer required")
+			}
+			d.compare(child, ps, to.Properties[name], depth+1)
+		}
+	}
+}
+
+func (d *differ) compareEnum(path string, from *Schema, to *Schema) {
+	switch {
+	case len(from.Enum) == 0 && len(to.Enum) == 0:
+		return
+	case len(to.Enum) == 0:
+		d.add(ChangeEnumWidened, path, false, "allowed values are no longer restricted")
+		return
+	case len(from.Enum) == 0:
+		d.add(ChangeEnumNarrowed, path, true, "values restricted to %s", formatValues(to.Enum))
+		return
+	}
+
+	removed := missingValues(from.Enum, to.Enum)
+	added := missingValues(to.Enum, from.Enum)
+	if len(removed) > 0 {
+		d.add(ChangeEnumNarrowed, path, true, "allowed values %s removed", formatValues(removed))
+	}
+	if len(added) > 0 {
+		d.add(ChangeEnumWidened, path, false, "allowed values %s added", formatValues(added))
+	}
+}
+
+// compareConstraints compares the keywords which restrict the values of a
+// type, where a lower maximum or a higher minimum is tightening.
+func (d *differ) compareConstraints(path string, from *Schema, to *Schema) {
+	d.compareLimit(path, "maxLength", intLimit(from.MaxLength), intLimit(to.MaxLength), true)
+	d.compareLimit(path, "minLength", intLimit(from.MinLength), intLimit(to.MinLength), false)
+	d.compareLimit(path, "maxItems", intLimit(from.MaxItems), intLimit(to.MaxItems), true)
+	d.compareLimit(path, "minItems", intLimit(from.MinItems), intLimit(to.MinItems), false)
+	d.compareLimit(path, "maximum", from.Maximum, to.Maximum, true)
+	d.compareLimit(path, "minimum", from.Minimum, to.Minimum, false)
+
+	if from.Pattern != to.Pattern {
+		if to.Pattern == "" {
+			d.add(ChangeConstraintLoosened, path, false, "pattern removed")
+		} else {
+			d.add(ChangeConstraintTightened, path, true, "pattern changed to %s", to.Pattern)
+		}
+	}
+}
+
+func (d *differ) compareLimit(path string, keyword string, from *float64, to *float64, maximum bool) {
+	var tightened bool
+	switch {
+	case from == nil && to == nil:
+		return
+	case from == nil:
+		tightened = true
+	case to == nil:
+		tightened = false
+	case *from == *to:
+		return
+	default:
+		tightened = *to < *from == maximum
+	}
+
+	switch {
+	case tightened && to != nil:
+		d.add(ChangeConstraintTightened, path, true, "%s changed to %v", keyword, *to)
+	case to != nil:
+		d.add(ChangeConstraintLoosened, path, false, "%s changed to %v", keyword, *to)
+	default:
+		d.add(ChangeConstraintLoosened, path, false, "%s removed", keyword)
+	}
+}
+
+func intLimit(value *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	result := float64(*value)
+	return &result
+}
+
+// allTypesAllowed reports whether every type in from is allowed by to
+func allTypesAllowed(from Types, to Types) bool {
+	for _, t := range from {
+		if !to.Has(t) && !(t == "integer" && to.Has("number")) {
+			return false
+		}
+	}
+	return true
+}
+
+func describeType(s *Schema) string {
+	if ref := s.TypeRef(); ref != "" {
+		return ref
+	}
+	if len(s.Type) == 0 {
+		return "any"
+	}
+	return strings.Join(s.Type, " or ")
+}
+
+// missingValues returns the values of a which are not in b
+func missingValues(a []interface{}, b []interface{}) []interface{} {
+	var result []interface{}
+	for _, value := range a {
+		found := false
+		for _, other := range b {
+			if reflect.DeepEqual(value, other) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	from := loadTestSchema(t, "banner.json")
+	to := loadTestSchema(t, "banner.json")
+
+	to.Properties["theme"].Enum = []interface{}{"dark", "contrast"}
+	to.Properties["priority"].Type = Types{"number"}
+	delete(to.Properties, "image")
+	to.Properties["subtitle"] = &Schema{Type: Types{"string"}}
+	to.Properties["headline"].MaxLength = nil
+	to.Definitions["link"].Required = append(to.Definitions["link"].Required, "label")
+	to.Properties["related"].Items.AllOf[1].Properties["contentType"].Enum = []interface{}{"https://example.com/other"}
+
+	changes := map[string][]ChangeKind{}
+	breaking := map[string]bool{}
+	for _, change := range Diff(from, to) {
+		changes[change.Path] = append(changes[change.Path], change.Kind)
+		breaking[change.Path] = breaking[change.Path] || change.Breaking
+	}
+
+	assert.Equal(t, map[string][]ChangeKind{
+		"/headline":              {ChangeConstraintLoosened},
+		"/theme":                 {ChangeEnumNarrowed, ChangeEnumWidened},
+		"/priority":              {ChangeTypeChanged},
+		"/image":                 {ChangePropertyRemoved},
+		"/subtitle":              {ChangePropertyAdded},
+		"/cta/label":             {ChangeRequiredAdded},
+		"/related/*/contentType": {ChangeEnumNarrowed, ChangeEnumWidened},
+	}, changes)
+	assert.Equal(t, map[string]bool{
+		"/headline":              false,
+		"/theme":                 true,
+		"/priority":              false,
+		"/image":                 true,
+		"/subtitle":              false,
+		"/cta/label":             true,
+		"/related/*/contentType": true,
+	}, breaking)
+
+	assert.Empty(t, Diff(from, loadTestSchema(t, "banner.json")))
+}
+
+func TestDiffTypes(t *testing.T) {
+	from := mustParse(t, `{
+		"properties": {
+			"any": {},
+			"link": {"$ref": "https://example.com/partials.json#/definitions/link"},
+			"moved": {"type": "string"}
+		}
+	}`)
+	to := mustParse(t, `{
+		"definitions": {
+			"link": {"type": "object"},
+			"text": {"type": "string"}
+		},
+		"properties": {
+			"any": {"type": "string"},
+			"link": {"$ref": "#/definitions/link"},
+			"moved": {"$ref": "#/definitions/text"}
+		}
+	}`)
+
+	assert.Equal(t, []Change{
+		{Kind: ChangeTypeChanged, Path: "/any", Breaking: true, Message: "type changed from any to string"},
+		{Kind: ChangeTypeChanged, Path: "/link", Breaking: true, Message: "type changed from https://example.com/partials.json#/definitions/link to object"},
+	}, Diff(from, to))
+}
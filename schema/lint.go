@@ -0,0 +1,396 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/labd/amplience-go-sdk/content"
+)
+
+// Severity of a lint finding, using the levels of SARIF
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// LintTarget is a schema to lint, together with the settings of the content
+// type schema it belongs to.
+type LintTarget struct {
+	Schema *Schema
+	// SchemaID and ValidationLevel are the values of the ContentTypeSchema, and
+	// are not checked when empty.
+	SchemaID        string
+	ValidationLevel string
+}
+
+// Rule is a lint rule. Check calls report for every problem it finds, with
+// the JSON pointer to the problem within the schema.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity
+	Check       func(target LintTarget, report func(pointer string, message string))
+}
+
+// Finding is a problem found by a lint rule
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	SchemaID string   `json:"schemaId"`
+	Pointer  string   `json:"pointer"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s#%s: %s (%s)", f.SchemaID, f.Pointer, f.Message, f.Rule)
+}
+
+// Linter checks schemas against a set of rules
+type Linter struct {
+	rules []Rule
+}
+
+// NewLinter creates a linter with the given rules, or with DefaultRules when
+// no rules are given.
+func NewLinter(rules ...Rule) *Linter {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	return &Linter{rules: rules}
+}
+
+// Rules returns the rules of the linter
+func (l *Linter) Rules() []Rule {
+	return l.rules
+}
+
+// Lint returns the findings of all rules for the targets
+func (l *Linter) Lint(targets ...LintTarget) []Finding {
+	var result []Finding
+	for _, target := range targets {
+		schemaID := target.Schema.ID
+		if schemaID == "" {
+			schemaID = target.SchemaID
+		}
+		for _, rule := range l.rules {
+			rule.Check(target, func(pointer string, message string) {
+				result = append(result, Finding{
+					Rule:     rule.ID,
+					Severity: rule.Severity,
+					SchemaID: schemaID,
+					Pointer:  pointer,
+					Message:  message,
+				})
+			})
+		}
+	}
+	return result
+}
+
+// LintContentTypeSchemas lints the bodies of content type schemas
+func (l *Linter) LintContentTypeSchemas(schemas ...content.ContentTypeSchema) ([]Finding, error) {
+	targets := make([]LintTarget, 0, len(schemas))
+	for _, s := range schemas {
+		parsed, err := ParseContentTypeSchema(s)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, LintTarget{Schema: parsed, SchemaID: s.SchemaID, ValidationLevel: s.ValidationLevel})
+	}
+	return l.Lint(targets...), nil
+}
+
+// TestingT is the part of testing.TB used by Check
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Check lints the targets and reports every finding with a severity of error
+// or warning as a test error. This allows linting schemas in the tests of a
+// project:
+//
+//	schema.NewLinter().Check(t, schema.LintTarget{Schema: banner})
+func (l *Linter) Check(t TestingT, targets ...LintTarget) {
+	t.Helper()
+	for _, finding := range l.Lint(targets...) {
+		if finding.Severity != SeverityNote {
+			t.Errorf("%s", finding)
+		}
+	}
+}
+
+// WriteJSON writes the findings as a JSON array
+func WriteJSON(w io.Writer, findings []Finding) error {
+	if findings == nil {
+		findings = []Finding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(findings)
+}
+
+// WriteSARIF writes the findings as a SARIF 2.1.0 log, which is supported by
+// code scanning tools such as GitHub code scanning.
+func WriteSARIF(w io.Writer, rules []Rule, findings []Finding) error {
+	type message struct {
+		Text string `json:"text"`
+	}
+	type sarifRule struct {
+		ID               string  `json:"id"`
+		ShortDescription message `json:"shortDescription"`
+		DefaultConfig    struct {
+			Level Severity `json:"level"`
+		} `json:"defaultConfiguration"`
+	}
+	type location struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+		} `json:"physicalLocation"`
+		LogicalLocations []struct {
+			FullyQualifiedName string `json:"fullyQualifiedName"`
+		} `json:"logicalLocations"`
+	}
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     Severity   `json:"level"`
+		Message   message    `json:"message"`
+		Locations []location `json:"locations"`
+	}
+
+	driverRules := make([]sarifRule, len(rules))
+	for i, rule := range rules {
+		driverRules[i].ID = rule.ID
+		driverRules[i].ShortDescription.Text = rule.Description
+		driverRules[i].DefaultConfig.Level = rule.Severity
+	}
+
+	results := make([]result, len(findings))
+	for i, finding := range findings {
+		loc := location{}
+		loc.PhysicalLocation.ArtifactLocation.URI = finding.SchemaID
+		loc.LogicalLocations = []struct {
+			FullyQualifiedName string `json:"fullyQualifiedName"`
+		}{{FullyQualifiedName: finding.Pointer}}
+
+		results[i] = result{
+			RuleID:    finding.Rule,
+			Level:     finding.Severity,
+			Message:   message{Text: finding.Message},
+			Locations: []location{loc},
+		}
+	}
+
+	log := map[string]interface{}{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []interface{}{
+			map[string]interface{}{
+				"tool": map[string]interface{}{
+					"driver": map[string]interface{}{
+						"name":  "amplience-schema-lint",
+						"rules": driverRules,
+					},
+				},
+				"results": results,
+			},
+		},
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// DefaultRules returns the built-in rules for the conventions of Amplience
+// schemas.
+func DefaultRules() []Rule {
+	return []Rule{
+		RuleIDMatchesSchemaID,
+		RulePropertyDocumentation,
+		RuleArrayMaxItems,
+		RuleContentLinkContentTypes,
+		RuleUnusedDefinitions,
+		RuleLocalizedCoreTypes,
+		RuleValidationLevel,
+	}
+}
+
+// RuleIDMatchesSchemaID checks that the `$id` of the schema is the SchemaID of
+// the content type schema.
+var RuleIDMatchesSchemaID = Rule{
+	ID:          "id-matches-schema-id",
+	Description: "The $id of the schema matches the schema id of the content type schema",
+	Severity:    SeverityError,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		switch {
+		case target.Schema.ID == "":
+			report("/$id", "schema has no $id")
+		case target.SchemaID != "" && target.Schema.ID != target.SchemaID:
+			report("/$id", fmt.Sprintf("$id %s does not match the schema id %s", target.Schema.ID, target.SchemaID))
+		}
+	},
+}
+
+// RulePropertyDocumentation checks that every property has a title and a
+// description, which are shown to editors.
+var RulePropertyDocumentation = Rule{
+	ID:          "property-documentation",
+	Description: "Every property has a title and a description",
+	Severity:    SeverityWarning,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		walkProperties(target.Schema, "", func(pointer string, name string, s *Schema) {
+			if name == "_meta" {
+				return
+			}
+			if s.Title == "" {
+				report(pointer, fmt.Sprintf("property %s has no title", name))
+			}
+			if s.Description == "" {
+				report(pointer, fmt.Sprintf("property %s has no description", name))
+			}
+		})
+	},
+}
+
+// RuleArrayMaxItems checks that arrays have a maximum number of items
+var RuleArrayMaxItems = Rule{
+	ID:          "array-max-items",
+	Description: "Arrays have maxItems",
+	Severity:    SeverityWarning,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		walkProperties(target.Schema, "", func(pointer string, name string, s *Schema) {
+			if s.Type.Has("array") && s.MaxItems == nil {
+				report(pointer, fmt.Sprintf("array %s has no maxItems", name))
+			}
+		})
+	},
+}
+
+// RuleContentLinkContentTypes checks that content links and references
+// restrict the content types they can refer to with an enum.
+var RuleContentLinkContentTypes = Rule{
+	ID:          "content-link-content-types",
+	Description: "Content links and references restrict the allowed content types",
+	Severity:    SeverityWarning,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		target.Schema.Walk(func(pointer string, s *Schema) bool {
+			def, ok := CoreDefinition(s.TypeRef())
+			if !ok || def != "content-link" && def != "content-reference" {
+				return true
+			}
+			for _, sub := range s.AllOf {
+				if contentType, ok := sub.Properties["contentType"]; ok && len(contentType.Enum) > 0 {
+					return false
+				}
+			}
+			report(pointer, fmt.Sprintf("%s does not restrict the allowed content types with an enum", def))
+			return false
+		})
+	},
+}
+
+// RuleUnusedDefinitions checks that every definition is used within the
+// schema. Partials are skipped, since their definitions are used by other
+// schemas.
+var RuleUnusedDefinitions = Rule{
+	ID:          "unused-definitions",
+	Description: "Every definition is used",
+	Severity:    SeverityWarning,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		if target.ValidationLevel == content.ValidationLevelPartial {
+			return
+		}
+
+		used := map[string]bool{}
+		target.Schema.Walk(func(pointer string, s *Schema) bool {
+			base, fragment, _ := strings.Cut(s.Ref, "#")
+			if base == "" || normalizeID(base) == normalizeID(target.Schema.ID) {
+				used[fragment] = true
+			}
+			return true
+		})
+
+		for _, name := range sortedKeys(target.Schema.Definitions) {
+			pointer := "/definitions/" + escapePointer(name)
+			if !used[pointer] {
+				report(pointer, fmt.Sprintf("definition %s is not used", name))
+			}
+		}
+	},
+}
+
+// RuleLocalizedCoreTypes checks that localized fields use the localized types
+// of the core schema, instead of defining the same structure themselves.
+var RuleLocalizedCoreTypes = Rule{
+	ID:          "localized-core-types",
+	Description: "Localized fields use the localized types of the core schema",
+	Severity:    SeverityWarning,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		walkProperties(target.Schema, "", func(pointer string, name string, s *Schema) {
+			if def, ok := CoreDefinition(s.TypeRef()); ok && strings.HasPrefix(def, "localized-") {
+				return
+			}
+			values, ok := s.Properties["values"]
+			if !ok || values.Items == nil {
+				return
+			}
+			_, hasLocale := values.Items.Properties["locale"]
+			_, hasValue := values.Items.Properties["value"]
+			if hasLocale && hasValue {
+				report(pointer, fmt.Sprintf("localized property %s does not use a localized type of the core schema", name))
+			}
+		})
+	},
+}
+
+// RuleValidationLevel checks that the validation level matches the shape of
+// the schema: content types and slots extend the core content schema, while
+// partials only contain definitions.
+var RuleValidationLevel = Rule{
+	ID:          "validation-level",
+	Description: "The validation level is consistent with the schema",
+	Severity:    SeverityError,
+	Check: func(target LintTarget, report func(pointer string, message string)) {
+		s := target.Schema
+		switch target.ValidationLevel {
+		case content.ValidationLevelContentType, content.ValidationLevelSlot:
+			if !s.IsContent() {
+				report("/allOf", fmt.Sprintf("%s schema does not extend %s", target.ValidationLevel, content.ContentSchema))
+			}
+		case content.ValidationLevelPartial:
+			if s.IsContent() {
+				report("/allOf", fmt.Sprintf("partial schema extends %s", content.ContentSchema))
+			}
+			if len(s.Properties) > 0 {
+				report("/properties", "partial schema has properties, which are only used through definitions")
+			}
+			if len(s.Definitions) == 0 {
+				report("/definitions", "partial schema has no definitions")
+			}
+		}
+	},
+}
+
+// walkProperties calls fn for every property of the schema, including those
+// in nested objects, array items and definitions.
+func walkProperties(s *Schema, pointer string, fn func(pointer string, name string, s *Schema)) {
+	for _, name := range sortedKeys(s.Definitions) {
+		walkProperties(s.Definitions[name], pointer+"/definitions/"+escapePointer(name), fn)
+	}
+	for _, name := range s.OrderedProperties() {
+		child := pointer + "/properties/" + escapePointer(name)
+		fn(child, name, s.Properties[name])
+		walkProperties(s.Properties[name], child, fn)
+	}
+	if s.Items != nil {
+		walkProperties(s.Items, pointer+"/items", fn)
+	}
+}
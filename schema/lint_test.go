@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/labd/amplience-go-sdk/content"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestLint(t *testing.T) {
+	banner := loadTestSchema(t, "banner.json")
+	target := LintTarget{Schema: banner, SchemaID: "https://example.com/banner", ValidationLevel: content.ValidationLevelContentType}
+	NewLinter().Check(t, target)
+
+	banner.Properties["tags"] = &Schema{Type: Types{"array"}, Title: "Tags"}
+	banner.Properties["link"] = &Schema{AllOf: []*Schema{{Ref: content.ContentLinkSchema}}, Title: "Link", Description: "Link"}
+	banner.Definitions["unused"] = &Schema{Type: Types{"string"}}
+	target.SchemaID = "https://example.com/other"
+	target.ValidationLevel = content.ValidationLevelPartial
+
+	rules := map[string][]string{}
+	for _, finding := range NewLinter().Lint(target) {
+		rules[finding.Rule] = append(rules[finding.Rule], finding.Pointer)
+	}
+	assert.Equal(t, map[string][]string{
+		"id-matches-schema-id":       {"/$id"},
+		"property-documentation":     {"/properties/tags"},
+		"array-max-items":            {"/properties/tags"},
+		"content-link-content-types": {"/properties/link"},
+		"validation-level":           {"/allOf", "/properties"},
+	}, rules)
+
+	r := &recorder{}
+	NewLinter(RuleArrayMaxItems).Check(r, target)
+	assert.Len(t, r.errors, 1)
+
+	buf := &bytes.Buffer{}
+	linter := NewLinter()
+	assert.NoError(t, WriteSARIF(buf, linter.Rules(), linter.Lint(target)))
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs[0].Results, 6)
+}
+
+func TestLintDefinitionsAndLocalizedTypes(t *testing.T) {
+	banner := loadTestSchema(t, "banner.json")
+	banner.Definitions["unused"] = &Schema{Type: Types{"string"}}
+	banner.Properties["subtitle"] = mustParse(t, `{
+		"title": "Subtitle",
+		"description": "Subtitle",
+		"type": "object",
+		"properties": {
+			"values": {
+				"type": "array",
+				"maxItems": 10,
+				"items": {
+					"type": "object",
+					"properties": {"locale": {"type": "string"}, "value": {"type": "string"}}
+				}
+			}
+		}
+	}`)
+	target := LintTarget{Schema: banner, SchemaID: banner.ID, ValidationLevel: content.ValidationLevelContentType}
+
+	linter := NewLinter(RuleUnusedDefinitions, RuleLocalizedCoreTypes)
+	findings := linter.Lint(target)
+	rules := map[string][]string{}
+	for _, finding := range findings {
+		rules[finding.Rule] = append(rules[finding.Rule], finding.Pointer)
+	}
+	assert.Equal(t, map[string][]string{
+		"unused-definitions":   {"/definitions/unused"},
+		"localized-core-types": {"/properties/subtitle"},
+	}, rules)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteSARIF(buf, linter.Rules(), findings))
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RuleID string `json:"ruleId"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	var ids []string
+	for _, result := range log.Runs[0].Results {
+		ids = append(ids, result.RuleID)
+	}
+	assert.ElementsMatch(t, []string{"unused-definitions", "localized-core-types"}, ids)
+}
@@ -0,0 +1,350 @@
+package schema
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/labd/amplience-go-sdk/content"
+)
+
+var (
+	// ErrMissingRef is returned for a `$ref` to a schema or definition which
+	// does not exist.
+	ErrMissingRef = errors.New("missing $ref")
+
+	// ErrRefCycle is returned for `$ref`s which refer back to themselves,
+	// directly or through other schemas.
+	ErrRefCycle = errors.New("$ref cycle")
+)
+
+//go:embed core.json
+var coreSchemaData []byte
+
+// coreSchema is a bundled copy of the Amplience core schema, so schemas which
+// refer to it can be used offline.
+var coreSchema = func() *Schema {
+	result, err := Parse(coreSchemaData)
+	if err != nil {
+		panic(fmt.Sprintf("invalid core schema: %s", err))
+	}
+	return result
+}()
+
+// CoreSchema returns a copy of the bundled Amplience core schema
+func CoreSchema() *Schema {
+	return coreSchema.copy()
+}
+
+// RefError is an error for a `$ref` in a schema
+type RefError struct {
+	// SchemaID is the id of the schema containing the `$ref`
+	SchemaID string
+	// Pointer is the JSON pointer to the `$ref` within the schema
+	Pointer string
+	Ref     string
+	Err     error
+}
+
+func (e *RefError) Error() string {
+	return fmt.Sprintf("%s#%s: %s %s", e.SchemaID, e.Pointer, e.Err, e.Ref)
+}
+
+func (e *RefError) Unwrap() error {
+	return e.Err
+}
+
+// Registry holds schemas by their id, to resolve `$ref`s between them offline.
+// It always contains a bundled copy of the Amplience core schema.
+type Registry struct {
+	schemas map[string]*Schema
+}
+
+// NewRegistry creates a registry with the given schemas
+func NewRegistry(schemas ...*Schema) *Registry {
+	r := &Registry{schemas: map[string]*Schema{}}
+	r.Add(coreSchema)
+	for _, s := range schemas {
+		r.Add(s)
+	}
+	return r
+}
+
+// RegistryFromHub creates a registry with all active content type schemas of
+// the hub.
+func RegistryFromHub(client *content.Client, hubID string) (*Registry, error) {
+	return RegistryFromHubWithContext(context.Background(), client, hubID)
+}
+
+// RegistryFromHubWithContext is the same as RegistryFromHub with a custom context
+func RegistryFromHubWithContext(ctx context.Context, client *content.Client, hubID string) (*Registry, error) {
+	r := NewRegistry()
+	for item, err := range client.ContentTypeSchemaIterateWithContext(ctx, hubID, content.StatusPaginationParameters{Status: content.StatusActive}) {
+		if err != nil {
+			return nil, err
+		}
+		s, err := ParseContentTypeSchema(item)
+		if err != nil {
+			return nil, err
+		}
+		r.Add(s)
+	}
+	return r, nil
+}
+
+// Add adds a schema to the registry, replacing the schema with the same id
+func (r *Registry) Add(s *Schema) {
+	r.schemas[normalizeID(s.ID)] = s
+}
+
+// Get returns the schema with the given id
+func (r *Registry) Get(id string) (*Schema, bool) {
+	s, ok := r.schemas[normalizeID(id)]
+	return s, ok
+}
+
+// Schemas returns the ids of all schemas in the registry, except the core
+// schema.
+func (r *Registry) Schemas() []string {
+	var result []string
+	for _, id := range sortedKeys(r.schemas) {
+		if id != content.CoreSchemaID {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// Resolve returns the schema a `$ref` in root points to, together with the
+// schema containing it, which is the root for the `$ref`s within the target.
+func (r *Registry) Resolve(root *Schema, ref string) (*Schema, *Schema, error) {
+	return resolveRef(root, ref, r.Get)
+}
+
+// Check checks the `$ref`s of all schemas in the registry, and returns a
+// RefError for every missing reference and cycle.
+func (r *Registry) Check() []error {
+	var result []error
+	for _, id := range r.Schemas() {
+		s := r.schemas[id]
+		s.Walk(func(pointer string, sub *Schema) bool {
+			if sub.Ref == "" {
+				return true
+			}
+			if _, _, err := r.Resolve(s, sub.Ref); err != nil {
+				result = append(result, &RefError{SchemaID: s.ID, Pointer: pointer, Ref: sub.Ref, Err: ErrMissingRef})
+			}
+			return true
+		})
+
+		d := &dereferencer{registry: r, stack: map[string]bool{}}
+		if _, err := d.dereference(s, s, ""); errors.Is(err, ErrRefCycle) {
+			result = append(result, err)
+		}
+		for _, def := range sortedKeys(s.Definitions) {
+			if _, err := d.dereference(s, s.Definitions[def], "/definitions/"+def); errors.Is(err, ErrRefCycle) {
+				result = append(result, err)
+			}
+		}
+	}
+	return result
+}
+
+// Dereference returns a copy of the schema with the given id, where every
+// `$ref` is replaced by the schema it refers to. Since all references are
+// inlined, the `definitions` are left out. Schemas which refer to themselves
+// can not be dereferenced, for those an error wrapping ErrRefCycle is
+// returned.
+func (r *Registry) Dereference(id string) (*Schema, error) {
+	s, ok := r.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown schema %s", ErrMissingRef, id)
+	}
+	d := &dereferencer{registry: r, stack: map[string]bool{}}
+	return d.dereference(s, s, "")
+}
+
+// Bundle returns a copy of the schema with the given id, which does not refer
+// to any other schema. Definitions of other schemas, including the core
+// schema, are copied into its `definitions` and the `$ref`s are changed to
+// refer to the copies.
+func (r *Registry) Bundle(id string) (*Schema, error) {
+	s, ok := r.Get(id)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown schema %s", ErrMissingRef, id)
+	}
+
+	b := &bundler{registry: r, root: s, names: map[string]string{}}
+	result := s.copy()
+	if result.Definitions == nil {
+		result.Definitions = map[string]*Schema{}
+	}
+	b.definitions = result.Definitions
+	b.used = map[string]bool{}
+	for name := range result.Definitions {
+		b.used[name] = true
+	}
+
+	if err := b.rewrite(s, result, ""); err != nil {
+		return nil, err
+	}
+	if len(result.Definitions) == 0 {
+		result.Definitions = nil
+	}
+	return result, nil
+}
+
+type dereferencer struct {
+	registry *Registry
+	stack    map[string]bool
+}
+
+// dereference returns a copy of s, which is part of root, with all `$ref`s
+// replaced.
+func (d *dereferencer) dereference(root *Schema, s *Schema, pointer string) (*Schema, error) {
+	result := s.copy()
+	result.Definitions = nil
+
+	var err error
+	result.forEachChild(func(keyword string, child **Schema) {
+		if err == nil {
+			*child, err = d.dereference(root, *child, pointer+keyword)
+		}
+	})
+	if err != nil || s.Ref == "" {
+		return result, err
+	}
+
+	refRoot, target, err := d.registry.Resolve(root, s.Ref)
+	if err != nil {
+		return nil, &RefError{SchemaID: root.ID, Pointer: pointer, Ref: s.Ref, Err: ErrMissingRef}
+	}
+
+	key := refKey(refRoot, s.Ref)
+	if d.stack[key] {
+		return nil, &RefError{SchemaID: root.ID, Pointer: pointer, Ref: s.Ref, Err: ErrRefCycle}
+	}
+	d.stack[key] = true
+	resolved, err := d.dereference(refRoot, target, "")
+	delete(d.stack, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// A `$ref` with only a title and description is replaced by its target,
+	// otherwise the other keywords are combined with the target in an allOf.
+	result.Ref = ""
+	annotations := &Schema{Title: result.Title, Description: result.Description}
+	if result.Title, result.Description = "", ""; result.isEmpty() {
+		if annotations.Title != "" {
+			resolved.Title = annotations.Title
+		}
+		if annotations.Description != "" {
+			resolved.Description = annotations.Description
+		}
+		return resolved, nil
+	}
+
+	result.Title, result.Description = annotations.Title, annotations.Description
+	result.AllOf = append([]*Schema{resolved}, result.AllOf...)
+	return result, nil
+}
+
+type bundler struct {
+	registry    *Registry
+	root        *Schema
+	definitions map[string]*Schema
+	names       map[string]string
+	used        map[string]bool
+}
+
+// rewrite changes the `$ref`s in s, which is the copy of original within
+// root, to refer to definitions of the bundle.
+func (b *bundler) rewrite(root *Schema, s *Schema, pointer string) error {
+	var err error
+	s.forEachChild(func(keyword string, child **Schema) {
+		if err == nil {
+			err = b.rewrite(root, *child, pointer+keyword)
+		}
+	})
+	if err != nil || s.Ref == "" {
+		return err
+	}
+
+	refRoot, target, err := b.registry.Resolve(root, s.Ref)
+	if err != nil {
+		return &RefError{SchemaID: root.ID, Pointer: pointer, Ref: s.Ref, Err: ErrMissingRef}
+	}
+
+	// References within the bundled schema itself stay as they are
+	if refRoot == b.root {
+		_, fragment, _ := strings.Cut(s.Ref, "#")
+		s.Ref = "#" + fragment
+		return nil
+	}
+
+	key := refKey(refRoot, s.Ref)
+	if name, ok := b.names[key]; ok {
+		s.Ref = "#/definitions/" + name
+		return nil
+	}
+
+	name := b.definitionName(refRoot, s.Ref)
+	b.names[key] = name
+	s.Ref = "#/definitions/" + name
+
+	definition := target.copy()
+	definition.ID = ""
+	definition.Schema = ""
+	definition.Definitions = nil
+	b.definitions[name] = definition
+	return b.rewrite(refRoot, definition, "/definitions/"+name)
+}
+
+// definitionName returns a unique name for the copy of a referenced schema,
+// based on the last part of the schema id and the name of the definition.
+func (b *bundler) definitionName(root *Schema, ref string) string {
+	_, fragment, _ := strings.Cut(ref, "#")
+	name := path.Base(strings.TrimSuffix(normalizeID(root.ID), ".json"))
+	if fragment != "" {
+		name += "-" + path.Base(fragment)
+	}
+
+	result := name
+	for i := 2; b.used[result]; i++ {
+		result = fmt.Sprintf("%s-%d", name, i)
+	}
+	b.used[result] = true
+	return result
+}
+
+// resolveRef resolves a `$ref` in root, using lookup to find other schemas
+func resolveRef(root *Schema, ref string, lookup func(id string) (*Schema, bool)) (*Schema, *Schema, error) {
+	base, fragment, _ := strings.Cut(ref, "#")
+	target := root
+	if base != "" && normalizeID(base) != normalizeID(root.ID) {
+		var ok bool
+		if target, ok = lookup(base); !ok {
+			return nil, nil, fmt.Errorf("%w: unknown schema %s in %s", ErrMissingRef, base, ref)
+		}
+	}
+
+	result, ok := target.Lookup(fragment)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %s does not exist", ErrMissingRef, ref)
+	}
+	return target, result, nil
+}
+
+// refKey identifies the target of a `$ref` across schemas
+func refKey(root *Schema, ref string) string {
+	_, fragment, _ := strings.Cut(ref, "#")
+	return normalizeID(root.ID) + "#" + fragment
+}
+
+func normalizeID(id string) string {
+	return strings.TrimSuffix(id, "#")
+}
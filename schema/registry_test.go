@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustParse(t *testing.T, data string) *Schema {
+	result, err := Parse([]byte(data))
+	assert.NoError(t, err)
+	return result
+}
+
+func TestRegistry(t *testing.T) {
+	partial := mustParse(t, `{
+		"$id": "https://example.com/partials",
+		"definitions": {
+			"link": {"type": "object", "properties": {"url": {"type": "string"}, "image": {"$ref": "http://bigcontent.io/cms/schema/v1/core#/definitions/image-link"}}},
+			"loop": {"$ref": "#/definitions/loop"}
+		}
+	}`)
+	page := mustParse(t, `{
+		"$id": "https://example.com/page",
+		"allOf": [{"$ref": "http://bigcontent.io/cms/schema/v1/core#/definitions/content"}],
+		"type": "object",
+		"properties": {
+			"cta": {"title": "CTA", "$ref": "https://example.com/partials#/definitions/link"},
+			"missing": {"$ref": "https://example.com/partials#/definitions/missing"}
+		}
+	}`)
+	registry := NewRegistry(partial, page)
+	assert.Equal(t, []string{"https://example.com/page", "https://example.com/partials"}, registry.Schemas())
+
+	errs := registry.Check()
+	assert.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0], ErrMissingRef)
+	assert.Contains(t, errs[0].Error(), "/properties/missing")
+	assert.ErrorIs(t, errs[1], ErrRefCycle)
+
+	delete(page.Properties, "missing")
+	errs = registry.Check()
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrRefCycle)
+
+	dereferenced, err := registry.Dereference("https://example.com/page")
+	assert.NoError(t, err)
+	assert.Equal(t, "CTA", dereferenced.Properties["cta"].Title)
+	assert.Equal(t, "string", dereferenced.Properties["cta"].Properties["url"].Type[0])
+	assert.Contains(t, dereferenced.Properties["cta"].Properties["image"].Required, "defaultHost")
+
+	bundle, err := registry.Bundle("https://example.com/page")
+	assert.NoError(t, err)
+	data, err := json.Marshal(bundle)
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(string(data), `"$ref":"http`), string(data))
+	assert.Equal(t, "#/definitions/partials-link", bundle.Properties["cta"].Ref)
+	assert.Equal(t, "#/definitions/core-image-link", bundle.Definitions["partials-link"].Properties["image"].Ref)
+	assert.Contains(t, bundle.Definitions, "core-content")
+	assert.Contains(t, bundle.Definitions, "core-meta")
+}
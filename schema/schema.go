@@ -0,0 +1,381 @@
+// Package schema works with the JSON Schemas of Amplience content types
+// offline, for example to generate Go types from them.
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/labd/amplience-go-sdk/content"
+)
+
+// Schema is a JSON Schema as used by Amplience content type schemas. Keywords
+// which are not part of the struct are kept in Extra.
+type Schema struct {
+	Schema        string             `json:"$schema,omitempty"`
+	ID            string             `json:"$id,omitempty"`
+	Ref           string             `json:"$ref,omitempty"`
+	Title         string             `json:"title,omitempty"`
+	Description   string             `json:"description,omitempty"`
+	Type          Types              `json:"type,omitempty"`
+	Format        string             `json:"format,omitempty"`
+	Enum          []interface{}      `json:"enum,omitempty"`
+	Const         interface{}        `json:"const,omitempty"`
+	Default       interface{}        `json:"default,omitempty"`
+	Properties    map[string]*Schema `json:"properties,omitempty"`
+	PropertyOrder []string           `json:"propertyOrder,omitempty"`
+	Required      []string           `json:"required,omitempty"`
+	Items         *Schema            `json:"items,omitempty"`
+	MinItems      *int               `json:"minItems,omitempty"`
+	MaxItems      *int               `json:"maxItems,omitempty"`
+	UniqueItems   bool               `json:"uniqueItems,omitempty"`
+	MinLength     *int               `json:"minLength,omitempty"`
+	MaxLength     *int               `json:"maxLength,omitempty"`
+	Pattern       string             `json:"pattern,omitempty"`
+	Minimum       *float64           `json:"minimum,omitempty"`
+	Maximum       *float64           `json:"maximum,omitempty"`
+	AllOf         []*Schema          `json:"allOf,omitempty"`
+	AnyOf         []*Schema          `json:"anyOf,omitempty"`
+	OneOf         []*Schema          `json:"oneOf,omitempty"`
+	Not           *Schema            `json:"not,omitempty"`
+	Definitions   map[string]*Schema `json:"definitions,omitempty"`
+
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+
+	// Extra holds the keywords which are not known, such as the `trait:` and
+	// `ui:` extensions of Amplience.
+	Extra map[string]json.RawMessage `json:"-"`
+
+	// boolean is set for the schemas `true` and `false`
+	boolean *bool
+}
+
+// knownKeywords are the json names of the fields of Schema
+var knownKeywords = func() map[string]bool {
+	result := map[string]bool{}
+	t := reflect.TypeOf(Schema{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			result[name] = true
+		}
+	}
+	return result
+}()
+
+// Parse parses a JSON Schema
+func Parse(data []byte) (*Schema, error) {
+	result := &Schema{}
+	if err := json.Unmarshal(data, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ParseContentTypeSchema parses the Body of a content type schema. When the
+// body has no `$id` the SchemaID is used.
+func ParseContentTypeSchema(s content.ContentTypeSchema) (*Schema, error) {
+	result, err := Parse([]byte(s.Body))
+	if err != nil {
+		return nil, fmt.Errorf("schema %s: %w", s.SchemaID, err)
+	}
+	if result.ID == "" {
+		result.ID = s.SchemaID
+	}
+	return result, nil
+}
+
+// UnmarshalJSON is a custom unmarshaller which also accepts the boolean
+// schemas, and keeps unknown keywords in Extra.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if string(data) == "true" || string(data) == "false" {
+		value := string(data) == "true"
+		*s = Schema{boolean: &value}
+		return nil
+	}
+
+	type schema Schema
+	if err := json.Unmarshal(data, (*schema)(s)); err != nil {
+		return err
+	}
+
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for key, value := range fields {
+		if knownKeywords[key] {
+			continue
+		}
+		if s.Extra == nil {
+			s.Extra = map[string]json.RawMessage{}
+		}
+		s.Extra[key] = value
+	}
+	return nil
+}
+
+// MarshalJSON is a custom marshaller which adds the keywords in Extra
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+
+	type schema Schema
+	data, err := json.Marshal(schema(s))
+	if err != nil || len(s.Extra) == 0 {
+		return data, err
+	}
+
+	fields := map[string]json.RawMessage{}
+	for key, value := range s.Extra {
+		fields[key] = value
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// Boolean returns the value of the schemas `true` and `false`, and whether the
+// schema is one of them.
+func (s *Schema) Boolean() (value bool, ok bool) {
+	if s == nil || s.boolean == nil {
+		return false, false
+	}
+	return *s.boolean, true
+}
+
+// IsRequired reports whether the property with the given name is required
+func (s *Schema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderedProperties returns the names of the properties, in the order of
+// `propertyOrder` followed by the remaining properties sorted by name.
+func (s *Schema) OrderedProperties() []string {
+	result := make([]string, 0, len(s.Properties))
+	seen := map[string]bool{}
+	for _, name := range s.PropertyOrder {
+		if _, ok := s.Properties[name]; ok && !seen[name] {
+			result = append(result, name)
+			seen[name] = true
+		}
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		if !seen[name] {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// Lookup returns the schema at a JSON pointer within the schema, such as
+// `/definitions/link`. An empty pointer returns the schema itself.
+func (s *Schema) Lookup(pointer string) (*Schema, bool) {
+	if pointer == "" {
+		return s, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	current := s
+	for i := 0; i < len(tokens) && current != nil; i++ {
+		keyword := tokens[i]
+		switch keyword {
+		case "items":
+			current = current.Items
+			continue
+		case "not":
+			current = current.Not
+			continue
+		case "additionalProperties":
+			current = current.AdditionalProperties
+			continue
+		}
+
+		if i++; i >= len(tokens) {
+			return nil, false
+		}
+		key := strings.NewReplacer("~1", "/", "~0", "~").Replace(tokens[i])
+
+		switch keyword {
+		case "definitions":
+			current = current.Definitions[key]
+		case "properties":
+			current = current.Properties[key]
+		case "allOf", "anyOf", "oneOf":
+			list := map[string][]*Schema{"allOf": current.AllOf, "anyOf": current.AnyOf, "oneOf": current.OneOf}[keyword]
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(list) {
+				return nil, false
+			}
+			current = list[index]
+		default:
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+// Walk calls fn for the schema and all schemas within it, with their JSON
+// pointer. When fn returns false the schemas within that schema are skipped.
+func (s *Schema) Walk(fn func(pointer string, s *Schema) bool) {
+	s.walk("", fn)
+}
+
+func (s *Schema) walk(pointer string, fn func(pointer string, s *Schema) bool) {
+	if !fn(pointer, s) {
+		return
+	}
+	s.forEachChild(func(keyword string, child **Schema) {
+		(*child).walk(pointer+keyword, fn)
+	})
+}
+
+// forEachChild calls fn for every schema directly within the schema, with the
+// part of the JSON pointer leading to it.
+func (s *Schema) forEachChild(fn func(keyword string, child **Schema)) {
+	for _, name := range sortedKeys(s.Definitions) {
+		child := s.Definitions[name]
+		fn("/definitions/"+escapePointer(name), &child)
+		s.Definitions[name] = child
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		child := s.Properties[name]
+		fn("/properties/"+escapePointer(name), &child)
+		s.Properties[name] = child
+	}
+	for i := range s.AllOf {
+		fn(fmt.Sprintf("/allOf/%d", i), &s.AllOf[i])
+	}
+	for i := range s.AnyOf {
+		fn(fmt.Sprintf("/anyOf/%d", i), &s.AnyOf[i])
+	}
+	for i := range s.OneOf {
+		fn(fmt.Sprintf("/oneOf/%d", i), &s.OneOf[i])
+	}
+	if s.Items != nil {
+		fn("/items", &s.Items)
+	}
+	if s.AdditionalProperties != nil {
+		fn("/additionalProperties", &s.AdditionalProperties)
+	}
+	if s.Not != nil {
+		fn("/not", &s.Not)
+	}
+}
+
+// copy returns a deep copy of the schema
+func (s *Schema) copy() *Schema {
+	data, err := json.Marshal(s)
+	if err != nil {
+		panic(fmt.Sprintf("schema can not be encoded: %s", err))
+	}
+	result, err := Parse(data)
+	if err != nil {
+		panic(fmt.Sprintf("schema can not be decoded: %s", err))
+	}
+	return result
+}
+
+// isEmpty reports whether the schema has no keywords
+func (s *Schema) isEmpty() bool {
+	data, err := json.Marshal(s)
+	return err == nil && string(data) == "{}"
+}
+
+// IsContent reports whether the schema extends the core content schema, which
+// is the case for content types and slots.
+func (s *Schema) IsContent() bool {
+	for _, sub := range s.AllOf {
+		if sub.Ref == content.ContentSchema {
+			return true
+		}
+	}
+	return false
+}
+
+// TypeRef returns the `$ref` which determines the type of the schema. Besides
+// `$ref` itself, Amplience uses an `allOf` combining a `$ref` with extra
+// constraints, for example to restrict the content types of a content link.
+func (s *Schema) TypeRef() string {
+	if s.Ref != "" {
+		return s.Ref
+	}
+	for _, sub := range s.AllOf {
+		if sub.Ref != "" && sub.Ref != content.ContentSchema {
+			return sub.Ref
+		}
+	}
+	return ""
+}
+
+// CoreDefinition returns the name of the core schema definition a `$ref`
+// points to, such as `content-link`.
+func CoreDefinition(ref string) (string, bool) {
+	prefix := content.CoreSchemaID + "#/definitions/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(ref, prefix), true
+}
+
+// Types is the value of the `type` keyword, which is either a single type or a
+// list of types.
+type Types []string
+
+// UnmarshalJSON is a custom unmarshaller which accepts a single type
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// MarshalJSON is a custom marshaller which encodes a single type as a string
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// Has reports whether the types contain the given type
+func (t Types) Has(name string) bool {
+	for _, item := range t {
+		if item == name {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,412 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labd/amplience-go-sdk/content"
+)
+
+// maxDepth limits the nesting of schemas, to stop on `$ref` cycles which do
+// not consume any of the value.
+const maxDepth = 64
+
+// ValidateOption configures how a body is validated
+type ValidateOption func(*validator)
+
+// WithValidationLevel sets the validation level of the schema, see the
+// content.ValidationLevel constants. By default a schema which extends the core
+// content schema is validated as a content type.
+func WithValidationLevel(level string) ValidateOption {
+	return func(v *validator) {
+		v.level = level
+	}
+}
+
+// WithSchemas makes other schemas available, which are used for `$ref`s to
+// those schemas.
+func WithSchemas(schemas ...*Schema) ValidateOption {
+	return func(v *validator) {
+		for _, s := range schemas {
+			v.schemas[normalizeID(s.ID)] = s
+		}
+	}
+}
+
+// WithRegistry uses the schemas of the registry for `$ref`s to other schemas
+func WithRegistry(registry *Registry) ValidateOption {
+	return func(v *validator) {
+		v.registry = registry
+	}
+}
+
+// Validate validates a content item body against a schema, and returns the
+// errors in the same shape as the API does. The Property of the errors is a
+// JSON pointer to the invalid value, and the Entity the id of the schema which
+// reported the error.
+func Validate(s *Schema, body map[string]interface{}, opts ...ValidateOption) []content.ErrorObject {
+	v := &validator{
+		schemas:  map[string]*Schema{},
+		registry: NewRegistry(),
+	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	if v.level == "" && s.IsContent() {
+		v.level = content.ValidationLevelContentType
+	}
+
+	// Encode and decode the body, so it only contains the types produced by
+	// encoding/json, also when it was created by hand.
+	var value interface{}
+	data, err := json.Marshal(body)
+	if err == nil {
+		err = json.Unmarshal(data, &value)
+	}
+	if err != nil {
+		return []content.ErrorObject{{Entity: s.ID, Message: err.Error()}}
+	}
+
+	switch v.level {
+	case content.ValidationLevelPartial:
+		return []content.ErrorObject{{Entity: s.ID, Message: "partial schemas can not be used for content"}}
+	case content.ValidationLevelContentType, content.ValidationLevelSlot:
+		v.validateMeta(s, value)
+	}
+
+	v.validate(s, s, value, "", 0)
+	return v.errors
+}
+
+// ValidateContentItem validates a content item body against a content type
+// schema, using its ValidationLevel.
+func ValidateContentItem(s content.ContentTypeSchema, body map[string]interface{}, opts ...ValidateOption) ([]content.ErrorObject, error) {
+	parsed, err := ParseContentTypeSchema(s)
+	if err != nil {
+		return nil, err
+	}
+	if s.ValidationLevel != "" {
+		opts = append([]ValidateOption{WithValidationLevel(s.ValidationLevel)}, opts...)
+	}
+	return Validate(parsed, body, opts...), nil
+}
+
+type validator struct {
+	level    string
+	schemas  map[string]*Schema
+	registry *Registry
+	patterns map[string]*regexp.Regexp
+	errors   []content.ErrorObject
+}
+
+func (v *validator) addError(root *Schema, pointer string, value interface{}, format string, args ...interface{}) {
+	v.errors = append(v.errors, content.ErrorObject{
+		Entity:       root.ID,
+		Property:     pointer,
+		InvalidValue: invalidValue(value),
+		Message:      fmt.Sprintf(format, args...),
+	})
+}
+
+// validateMeta checks the `_meta` block of content, which must refer to the
+// schema it is validated against.
+func (v *validator) validateMeta(s *Schema, value interface{}) {
+	body, _ := value.(map[string]interface{})
+	meta, ok := body["_meta"].(map[string]interface{})
+	if !ok {
+		// Reported by the core content schema
+		return
+	}
+
+	if schema, ok := meta["schema"].(string); ok && s.ID != "" && schema != s.ID {
+		v.addError(s, "/_meta/schema", schema, "must be %s", s.ID)
+	}
+	if key, ok := meta["deliveryKey"].(string); ok && key != "" {
+		if err := content.ValidateDeliveryKey(key); err != nil {
+			v.addError(s, "/_meta/deliveryKey", key, "%s", err)
+		}
+	}
+}
+
+// matches reports whether the value is valid, without reporting errors
+func (v *validator) matches(root *Schema, s *Schema, value interface{}, pointer string, depth int) bool {
+	sub := &validator{level: v.level, schemas: v.schemas, registry: v.registry, patterns: v.patterns}
+	sub.validate(root, s, value, pointer, depth)
+	v.patterns = sub.patterns
+	return len(sub.errors) == 0
+}
+
+func (v *validator) validate(root *Schema, s *Schema, value interface{}, pointer string, depth int) {
+	if s == nil {
+		return
+	}
+	if allowed, ok := s.Boolean(); ok {
+		if !allowed {
+			v.addError(root, pointer, value, "is not allowed")
+		}
+		return
+	}
+	if depth > maxDepth {
+		v.addError(root, pointer, value, "schema is nested too deep, the $refs probably contain a cycle")
+		return
+	}
+
+	if s.Ref != "" {
+		refRoot, target, err := v.resolve(root, s.Ref)
+		if err != nil {
+			v.addError(root, pointer, nil, "%s", err)
+		} else {
+			v.validate(refRoot, target, value, pointer, depth+1)
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		v.validate(root, sub, value, pointer, depth+1)
+	}
+	if len(s.AnyOf) > 0 {
+		valid := false
+		for _, sub := range s.AnyOf {
+			if v.matches(root, sub, value, pointer, depth+1) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			v.addError(root, pointer, value, "must match at least one of the allowed schemas")
+		}
+	}
+	if len(s.OneOf) > 0 {
+		count := 0
+		for _, sub := range s.OneOf {
+			if v.matches(root, sub, value, pointer, depth+1) {
+				count++
+			}
+		}
+		if count != 1 {
+			v.addError(root, pointer, value, "must match exactly one of the allowed schemas, matches %d", count)
+		}
+	}
+	if s.Not != nil && v.matches(root, s.Not, value, pointer, depth+1) {
+		v.addError(root, pointer, value, "must not match the schema")
+	}
+
+	if len(s.Type) > 0 && !matchesType(s.Type, value) {
+		v.addError(root, pointer, value, "must be of type %s, not %s", strings.Join(s.Type, " or "), typeOf(value))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			v.addError(root, pointer, value, "must be one of %s", formatValues(s.Enum))
+		}
+	}
+	if s.Const != nil && !reflect.DeepEqual(s.Const, value) {
+		v.addError(root, pointer, value, "must be %s", formatValues([]interface{}{s.Const}))
+	}
+
+	switch value := value.(type) {
+	case string:
+		v.validateString(root, s, value, pointer)
+	case float64:
+		if s.Minimum != nil && value < *s.Minimum {
+			v.addError(root, pointer, value, "must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && value > *s.Maximum {
+			v.addError(root, pointer, value, "must be at most %v", *s.Maximum)
+		}
+	case []interface{}:
+		v.validateArray(root, s, value, pointer, depth)
+	case map[string]interface{}:
+		v.validateObject(root, s, value, pointer, depth)
+	}
+}
+
+func (v *validator) validateString(root *Schema, s *Schema, value string, pointer string) {
+	length := utf8.RuneCountInString(value)
+	if s.MinLength != nil && length < *s.MinLength {
+		v.addError(root, pointer, value, "must be at least %d characters", *s.MinLength)
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		v.addError(root, pointer, value, "must be at most %d characters", *s.MaxLength)
+	}
+
+	if s.Pattern != "" {
+		if v.patterns == nil {
+			v.patterns = map[string]*regexp.Regexp{}
+		}
+		re, ok := v.patterns[s.Pattern]
+		if !ok {
+			// Patterns which are not supported by Go are not checked
+			re, _ = regexp.Compile(s.Pattern)
+			v.patterns[s.Pattern] = re
+		}
+		if re != nil && !re.MatchString(value) {
+			v.addError(root, pointer, value, "must match the pattern %s", s.Pattern)
+		}
+	}
+
+	if !validFormat(s.Format, value) {
+		v.addError(root, pointer, value, "must be a valid %s", s.Format)
+	}
+}
+
+func (v *validator) validateArray(root *Schema, s *Schema, value []interface{}, pointer string, depth int) {
+	if s.MinItems != nil && len(value) < *s.MinItems {
+		v.addError(root, pointer, nil, "must have at least %d items", *s.MinItems)
+	}
+	if s.MaxItems != nil && len(value) > *s.MaxItems {
+		v.addError(root, pointer, nil, "must have at most %d items", *s.MaxItems)
+	}
+	if s.UniqueItems {
+		for i := range value {
+			for j := i + 1; j < len(value); j++ {
+				if reflect.DeepEqual(value[i], value[j]) {
+					v.addError(root, fmt.Sprintf("%s/%d", pointer, j), value[j], "must be unique")
+				}
+			}
+		}
+	}
+	if s.Items != nil {
+		for i, item := range value {
+			v.validate(root, s.Items, item, fmt.Sprintf("%s/%d", pointer, i), depth+1)
+		}
+	}
+}
+
+func (v *validator) validateObject(root *Schema, s *Schema, value map[string]interface{}, pointer string, depth int) {
+	for _, name := range s.Required {
+		if _, ok := value[name]; !ok {
+			v.addError(root, pointer+"/"+escapePointer(name), nil, "is required")
+		}
+	}
+
+	for _, name := range sortedKeys(value) {
+		property := pointer + "/" + escapePointer(name)
+		if ps, ok := s.Properties[name]; ok {
+			v.validate(root, ps, value[name], property, depth+1)
+		} else if s.AdditionalProperties != nil {
+			if allowed, ok := s.AdditionalProperties.Boolean(); ok && !allowed {
+				v.addError(root, property, value[name], "is not a known property")
+				continue
+			}
+			v.validate(root, s.AdditionalProperties, value[name], property, depth+1)
+		}
+	}
+}
+
+// resolve returns the schema a `$ref` points to, and the schema containing it
+// which is used for the `$ref`s inside the target.
+func (v *validator) resolve(root *Schema, ref string) (*Schema, *Schema, error) {
+	return resolveRef(root, ref, func(id string) (*Schema, bool) {
+		if s, ok := v.schemas[normalizeID(id)]; ok {
+			return s, true
+		}
+		return v.registry.Get(id)
+	})
+}
+
+func matchesType(types Types, value interface{}) bool {
+	for _, t := range types {
+		switch value := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || t == "integer" && value == math.Trunc(value) {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func validFormat(format string, value string) bool {
+	var err error
+	switch format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, value)
+	case "date":
+		_, err = time.Parse("2006-01-02", value)
+	case "uri":
+		var u *url.URL
+		if u, err = url.Parse(value); err == nil && !u.IsAbs() {
+			return false
+		}
+	}
+	return err == nil
+}
+
+// invalidValue formats a value like the API does, strings as is and other
+// values as JSON. Objects and arrays are left out.
+func invalidValue(value interface{}) string {
+	switch value := value.(type) {
+	case nil, map[string]interface{}, []interface{}:
+		return ""
+	case string:
+		return value
+	default:
+		data, _ := json.Marshal(value)
+		return string(data)
+	}
+}
+
+func formatValues(values []interface{}) string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		data, _ := json.Marshal(value)
+		result[i] = string(data)
+	}
+	return strings.Join(result, ", ")
+}
+
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
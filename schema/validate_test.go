@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/labd/amplience-go-sdk/content"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	banner := loadTestSchema(t, "banner.json")
+
+	title := content.LocalizedValue[string]{}
+	title.Set("en-GB", "Hello")
+	body := map[string]interface{}{
+		"_meta":    map[string]interface{}{"schema": "https://example.com/banner", "deliveryKey": "home/banner"},
+		"headline": "Welcome",
+		"title":    title,
+		"theme":    "dark",
+		"priority": 1,
+		"cta":      map[string]interface{}{"url": "https://example.com"},
+		"related":  []interface{}{content.NewContentLink("https://example.com/banner", "item-id")},
+	}
+	assert.Empty(t, Validate(banner, body))
+
+	invalid := map[string]interface{}{
+		"_meta":    map[string]interface{}{"schema": "https://example.com/other", "deliveryKey": "/home"},
+		"theme":    "blue",
+		"priority": 1.5,
+		"title":    map[string]interface{}{"values": []interface{}{map[string]interface{}{"locale": "en-GB", "value": 1}}},
+		"cta":      map[string]interface{}{"url": "not a url"},
+		"related":  []interface{}{content.NewContentLink("https://example.com/other", "item-id")},
+	}
+	errors := map[string]string{}
+	for _, err := range Validate(banner, invalid) {
+		errors[err.Property] = err.Message
+	}
+	assert.Equal(t, map[string]string{
+		"/_meta/schema":          "must be https://example.com/banner",
+		"/_meta/deliveryKey":     "invalid delivery key: must not start or end with a /",
+		"/headline":              "is required",
+		"/theme":                 `must be one of "light", "dark"`,
+		"/priority":              "must be of type integer, not number",
+		"/title/_meta":           "is required",
+		"/title/values/0/value":  "must be of type string, not number",
+		"/cta/url":               "must be a valid uri",
+		"/related/0/contentType": `must be one of "https://example.com/banner"`,
+	}, errors)
+
+	errs := Validate(banner, body, WithValidationLevel(content.ValidationLevelPartial))
+	assert.Len(t, errs, 1)
+}